@@ -93,7 +93,7 @@ var matcherNameTranslationTable = map[string]string{
 }
 
 func translateMatcherFuncName(n string) string {
-	// TODO: What about the matcher Len, Nil, NotNil?
+	// TODO: What about the matcher Len?
 
 	if translated, ok := matcherNameTranslationTable[n]; ok {
 		return translated
@@ -5,6 +5,7 @@ package expect
 
 import (
 	"fmt"
+	"sync"
 )
 
 // Expectation defines an interface for types that perform an expectation.
@@ -46,22 +47,94 @@ type TB interface {
 
 // Expectations implements a context for running expectations.
 type Expectations struct {
+	// t is the TB expectations report to. It applies prefix to all messages.
 	t TB
+	// base is the TB passed to Using (or the TB of a subtest).
+	base    TB
+	prefix  string
+	explain bool
 }
 
 // Using creates a new Expectations value using t to interact with the test runner.
 func Using(t TB) *Expectations {
 	t.Helper()
-	return &Expectations{t: t}
+	return &Expectations{t: t, base: t}
+}
+
+// derive creates a new Expectations value reporting to base using prefix
+// and the configuration of e.
+func (e *Expectations) derive(base TB, prefix string) *Expectations {
+	t := base
+	if prefix != "" {
+		t = &prefixedTB{TB: base, prefix: prefix}
+	}
+
+	return &Expectations{
+		t:       t,
+		base:    base,
+		prefix:  prefix,
+		explain: e.explain,
+	}
 }
 
 // That runs all expecters using got as the actual value. You may run That multiple times and with the same
-// or different value for got.
+// or different value for got. Failures are prefixed with the source text of the failing expectation; see
+// ShowSourceExpressions.
 func (e *Expectations) That(expectations ...Expectation) *Expectations {
 	e.t.Helper()
+	return e.that(e.callSite(1, len(expectations), false), expectations)
+}
 
-	for _, expecter := range expectations {
-		expecter.Expect(e.t)
+// callSite captures the call to That if its source is needed by e.
+func (e *Expectations) callSite(skip int, n int, withTB bool) *callSite {
+	if !ShowSourceExpressions && !e.explain {
+		return nil
+	}
+	return newCallSite(skip+1, n, withTB)
+}
+
+// that runs expectations prefixing failures with the source text of the
+// failing expectation and explaining them if requested. The source is only
+// located once an expectation reports a failure.
+func (e *Expectations) that(site *callSite, expectations []Expectation) *Expectations {
+	e.t.Helper()
+
+	var envOnce sync.Once
+	var env *explainContext
+	explainContextFor := func() *explainContext {
+		envOnce.Do(func() {
+			if src := site.source(); src != nil {
+				env = newExplainContext(src, expectations)
+			}
+		})
+		return env
+	}
+
+	for i, expecter := range expectations {
+		i := i
+		t := e.t
+
+		// The explanation starts with the expression itself and is thus
+		// logged without the source prefix.
+		if site != nil && e.explain {
+			t = &explainingTB{TB: t, explain: func(values *failureValues) string {
+				if env := explainContextFor(); env != nil {
+					return env.explain(site.source().args[i], values)
+				}
+				return ""
+			}}
+		}
+
+		if site != nil && ShowSourceExpressions {
+			t = &prefixedTB{TB: t, prefixFunc: func() string {
+				if src := site.source(); src != nil {
+					return src.sf.text(src.args[i]) + ": "
+				}
+				return ""
+			}}
+		}
+
+		expecter.Expect(t)
 	}
 
 	return e
@@ -76,27 +149,57 @@ func (e *Expectations) WithMessage(format string, args ...any) *Expectations {
 		format = fmt.Sprintf(format, args...)
 	}
 
-	return Using(&prefixedTB{TB: e.t, prefix: format + ": "})
+	return e.derive(e.base, e.prefix+format+": ")
+}
+
+// Explain creates a new Expectations value that logs a tree of the values of the failing expectation's
+// sub-expressions whenever an expectation fails. Values are taken from the arguments captured by all
+// expectations passed to the same call of That (see WithArguments). Sub-expressions selecting fields, indexes
+// or map keys from these values or applying len or cap to them are evaluated as well. The got and want values
+// compared by the failing expectation are added if the expectation reports them (see ReportValues).
+// Explanations require the source of the calling test to be available.
+func (e *Expectations) Explain() *Expectations {
+	explained := e.derive(e.base, e.prefix)
+	explained.explain = true
+	return explained
 }
 
 type prefixedTB struct {
 	TB
 	prefix string
+	// prefixFunc, if set, is called to determine the prefix whenever it is
+	// needed. It is used for prefixes that are expensive to compute.
+	prefixFunc func() string
+}
+
+// Unwrap returns the wrapped TB.
+func (p *prefixedTB) Unwrap() TB { return p.TB }
+
+func (p *prefixedTB) currentPrefix() string {
+	if p.prefixFunc != nil {
+		return p.prefixFunc()
+	}
+	return p.prefix
 }
 
 func (p *prefixedTB) args(args []any) []any {
 	p.TB.Helper()
 
+	prefix := p.currentPrefix()
+	if prefix == "" {
+		return args
+	}
+
 	a := make([]any, len(args)+1)
 	copy(a[1:], args)
-	a[0] = p.prefix
+	a[0] = prefix
 	return a
 }
 
 func (p *prefixedTB) format(format string, args []any) string {
 	p.TB.Helper()
 
-	return fmt.Sprint(p.prefix, fmt.Sprintf(format, args...))
+	return fmt.Sprint(p.currentPrefix(), fmt.Sprintf(format, args...))
 }
 
 func (p *prefixedTB) Error(args ...any) {
@@ -142,7 +245,8 @@ func (p *prefixedTB) Skipf(format string, args ...any) {
 // That is a convenience function that runs all expecters on got reporting to t.
 func That(t TB, expectations ...Expectation) {
 	t.Helper()
-	Using(t).That(expectations...)
+	e := Using(t)
+	e.that(e.callSite(1, len(expectations), true), expectations)
 }
 
 // WithMessage is a convenience function to create an Expectations values with a pre-set prefix.
@@ -151,6 +255,12 @@ func WithMessage(t TB, format string, args ...any) *Expectations {
 	return Using(t).WithMessage(format, args...)
 }
 
+// Explain is a convenience function to create an Expectations value that explains failing expectations.
+func Explain(t TB) *Expectations {
+	t.Helper()
+	return Using(t).Explain()
+}
+
 // Fail is an Expectation that always fails.
 var Fail Expectation = ExpectFunc(func(t TB) { t.Error("test failed") })
 
@@ -169,6 +279,9 @@ type failNowTB struct {
 	TB
 }
 
+// Unwrap returns the wrapped TB.
+func (f *failNowTB) Unwrap() TB { return f.TB }
+
 func (f *failNowTB) Error(args ...any) {
 	f.TB.Fatal(args...)
 }
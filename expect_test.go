@@ -76,7 +76,7 @@ func TestWithMessage(t *testing.T) {
 	want := testhelper.TB{
 		ErrFlag: true,
 		Logs: []string{
-			"prefix: test failed",
+			"prefix: Fail: test failed",
 		},
 	}
 
@@ -93,7 +93,7 @@ func TestFailNow(t *testing.T) {
 	want := testhelper.TB{
 		FatalFlag: true,
 		Logs: []string{
-			"test failed",
+			"FailNow(Fail): test failed",
 		},
 	}
 
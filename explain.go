@@ -0,0 +1,430 @@
+package expect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ArgumentsProvider is an optional interface implemented by expectations that expose the values of the
+// arguments they have been created with. Explanations use these values to show the values of the expressions
+// used in the source.
+type ArgumentsProvider interface {
+	Arguments() []any
+}
+
+// WithArguments decorates e with the values of the arguments passed to the function creating e. Pass the
+// non-variadic arguments in the order they are declared so they can be matched with the source expressions.
+// Function values are ignored by explanations but must be passed to keep the order. All expectations
+// provided by package is capture their arguments.
+func WithArguments(e Expectation, args ...any) Expectation {
+	return &argumentsExpectation{Expectation: e, args: args}
+}
+
+type argumentsExpectation struct {
+	Expectation
+	args []any
+}
+
+func (a *argumentsExpectation) Arguments() []any { return a.args }
+
+// ReportValues records got and want as the values compared by an expectation that is about to fail. Call it
+// before reporting the failure. When the expectation is explained (see Explain), the values are shown as part
+// of the explanation. Otherwise, ReportValues does nothing. TB implementations wrapping another TB should
+// provide an Unwrap method returning the wrapped TB so that the values can be recorded.
+func ReportValues(t TB, got, want any) {
+	for t != nil {
+		if e, ok := t.(*explainingTB); ok {
+			e.values = &failureValues{got: got, want: want}
+			return
+		}
+
+		u, ok := t.(interface{ Unwrap() TB })
+		if !ok {
+			return
+		}
+		t = u.Unwrap()
+	}
+}
+
+// failureValues are the values reported using ReportValues.
+type failureValues struct {
+	got, want any
+}
+
+// explainingTB is a TB that logs an explanation after the first failure.
+type explainingTB struct {
+	TB
+	explain   func(values *failureValues) string
+	values    *failureValues
+	explained bool
+}
+
+// Unwrap returns the wrapped TB.
+func (e *explainingTB) Unwrap() TB { return e.TB }
+
+func (e *explainingTB) log() {
+	e.TB.Helper()
+
+	if e.explained {
+		return
+	}
+	e.explained = true
+
+	if s := e.explain(e.values); s != "" {
+		e.TB.Log(s)
+	}
+}
+
+func (e *explainingTB) Error(args ...any) {
+	e.TB.Helper()
+	e.TB.Error(args...)
+	e.log()
+}
+
+func (e *explainingTB) Errorf(format string, args ...any) {
+	e.TB.Helper()
+	e.TB.Errorf(format, args...)
+	e.log()
+}
+
+func (e *explainingTB) Fail() {
+	e.TB.Helper()
+	e.TB.Fail()
+	e.log()
+}
+
+// Fatal logs the explanation before reporting the failure as Fatal stops
+// the test's goroutine.
+func (e *explainingTB) Fatal(args ...any) {
+	e.TB.Helper()
+	e.log()
+	e.TB.Fatal(args...)
+}
+
+func (e *explainingTB) Fatalf(format string, args ...any) {
+	e.TB.Helper()
+	e.log()
+	e.TB.Fatalf(format, args...)
+}
+
+func (e *explainingTB) FailNow() {
+	e.TB.Helper()
+	e.log()
+	e.TB.FailNow()
+}
+
+// explainValue is a value known to an explanation.
+type explainValue struct {
+	v reflect.Value
+}
+
+func (v explainValue) String() string {
+	if !v.v.IsValid() {
+		return "nil"
+	}
+
+	if v.v.Kind() == reflect.String {
+		return strconv.Quote(v.v.String())
+	}
+
+	return fmt.Sprintf("%+v", v.v)
+}
+
+// explainEnv maps the source text of expressions to their values.
+type explainEnv map[string]explainValue
+
+// explainContext combines an explainEnv with the source file the
+// expressions are taken from.
+type explainContext struct {
+	env explainEnv
+	sf  *sourceFile
+}
+
+// newExplainContext collects the arguments captured by all expectations passed
+// to a single call of That.
+func newExplainContext(src *callSource, expectations []Expectation) *explainContext {
+	env := make(explainEnv)
+
+	for i, e := range expectations {
+		provider, ok := e.(ArgumentsProvider)
+		if !ok {
+			continue
+		}
+
+		call, ok := src.args[i].(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		for j, v := range provider.Arguments() {
+			if j >= len(call.Args) || (call.Ellipsis.IsValid() && j == len(call.Args)-1) {
+				break
+			}
+			rv := reflect.ValueOf(v)
+			if rv.Kind() == reflect.Func {
+				// Function values have no meaningful representation.
+				continue
+			}
+			env[src.sf.text(call.Args[j])] = explainValue{rv}
+		}
+	}
+
+	return &explainContext{env: env, sf: src.sf}
+}
+
+// explain renders the tree of known values for the expression expr
+// followed by the values reported by the failing expectation.
+func (c *explainContext) explain(expr ast.Expr, values *failureValues) string {
+	var b strings.Builder
+	b.WriteString(c.sf.text(expr))
+
+	children := c.children(expr)
+	if values != nil {
+		got, want := explainValue{reflect.ValueOf(values.got)}, explainValue{reflect.ValueOf(values.want)}
+		children = append(children, explainNode{text: "got", value: &got}, explainNode{text: "want", value: &want})
+	}
+
+	if len(children) == 0 {
+		return ""
+	}
+
+	c.writeNodes(&b, children, "")
+	return b.String()
+}
+
+// explainNode is a sub-expression with either a known value or known
+// values of some of its sub-expressions.
+type explainNode struct {
+	text     string
+	value    *explainValue
+	children []explainNode
+}
+
+// children returns the nodes for all sub-expressions of expr which have a
+// known value or known sub-expressions.
+func (c *explainContext) children(expr ast.Expr) []explainNode {
+	var nodes []explainNode
+
+	for _, sub := range subExpressions(expr) {
+		if _, ok := sub.(*ast.BasicLit); ok {
+			continue
+		}
+
+		n := explainNode{
+			text:     c.sf.text(sub),
+			children: c.children(sub),
+		}
+
+		if v, ok := c.eval(sub); ok {
+			n.value = &v
+		}
+
+		if n.value != nil || len(n.children) > 0 {
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+func (c *explainContext) writeNodes(b *strings.Builder, nodes []explainNode, indent string) {
+	for i, n := range nodes {
+		branch, childIndent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, childIndent = "└── ", "    "
+		}
+
+		b.WriteString("\n" + indent + branch + n.text)
+		if n.value != nil {
+			b.WriteString(" = " + n.value.String())
+		}
+
+		c.writeNodes(b, n.children, indent+childIndent)
+	}
+}
+
+// subExpressions returns the direct sub-expressions of expr worth
+// explaining.
+func subExpressions(expr ast.Expr) []ast.Expr {
+	switch x := expr.(type) {
+	case *ast.CallExpr:
+		var subs []ast.Expr
+		if sel, ok := x.Fun.(*ast.SelectorExpr); ok {
+			subs = append(subs, sel.X)
+		}
+		return append(subs, x.Args...)
+	case *ast.SelectorExpr:
+		return []ast.Expr{x.X}
+	case *ast.IndexExpr:
+		return []ast.Expr{x.X, x.Index}
+	case *ast.SliceExpr:
+		return []ast.Expr{x.X}
+	case *ast.StarExpr:
+		return []ast.Expr{x.X}
+	case *ast.UnaryExpr:
+		return []ast.Expr{x.X}
+	case *ast.BinaryExpr:
+		return []ast.Expr{x.X, x.Y}
+	case *ast.ParenExpr:
+		return []ast.Expr{x.X}
+	default:
+		return nil
+	}
+}
+
+// eval determines the value of expr either by looking it up or by
+// evaluating simple expressions based on known values.
+func (c *explainContext) eval(expr ast.Expr) (explainValue, bool) {
+	if v, ok := c.env[c.sf.text(expr)]; ok {
+		return v, true
+	}
+
+	switch x := expr.(type) {
+	case *ast.ParenExpr:
+		return c.eval(x.X)
+
+	case *ast.StarExpr:
+		v, ok := c.evalIndirect(x.X)
+		return explainValue{v}, ok
+
+	case *ast.SelectorExpr:
+		v, ok := c.evalIndirect(x.X)
+		if !ok || v.Kind() != reflect.Struct {
+			return explainValue{}, false
+		}
+
+		f := v.FieldByName(x.Sel.Name)
+		return explainValue{f}, f.IsValid()
+
+	case *ast.IndexExpr:
+		v, ok := c.evalIndirect(x.X)
+		if !ok {
+			return explainValue{}, false
+		}
+
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array, reflect.String:
+			i, ok := c.evalInt(x.Index)
+			if !ok || i < 0 || i >= v.Len() {
+				return explainValue{}, false
+			}
+			return explainValue{v.Index(i)}, true
+
+		case reflect.Map:
+			key, ok := c.evalMapKey(x.Index, v.Type().Key())
+			if !ok {
+				return explainValue{}, false
+			}
+			e := v.MapIndex(key)
+			return explainValue{e}, e.IsValid()
+		}
+
+	case *ast.CallExpr:
+		fun, ok := x.Fun.(*ast.Ident)
+		if !ok || (fun.Name != "len" && fun.Name != "cap") || len(x.Args) != 1 {
+			return explainValue{}, false
+		}
+
+		arg, ok := c.eval(x.Args[0])
+		if !ok || !arg.v.IsValid() {
+			return explainValue{}, false
+		}
+
+		v := arg.v
+		if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Array {
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Chan:
+			if fun.Name == "cap" {
+				return explainValue{reflect.ValueOf(v.Cap())}, true
+			}
+			return explainValue{reflect.ValueOf(v.Len())}, true
+		case reflect.String, reflect.Map:
+			if fun.Name == "len" {
+				return explainValue{reflect.ValueOf(v.Len())}, true
+			}
+		}
+	}
+
+	return explainValue{}, false
+}
+
+// evalIndirect evaluates expr following pointers and interfaces.
+func (c *explainContext) evalIndirect(expr ast.Expr) (reflect.Value, bool) {
+	v, ok := c.eval(expr)
+	if !ok {
+		return reflect.Value{}, false
+	}
+
+	r := v.v
+	for r.IsValid() && (r.Kind() == reflect.Ptr || r.Kind() == reflect.Interface) {
+		if r.IsNil() {
+			return reflect.Value{}, false
+		}
+		r = r.Elem()
+	}
+
+	return r, r.IsValid()
+}
+
+func (c *explainContext) evalInt(expr ast.Expr) (int, bool) {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.INT {
+		i, err := strconv.Atoi(lit.Value)
+		return i, err == nil
+	}
+
+	v, ok := c.evalIndirect(expr)
+	if !ok {
+		return 0, false
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), true
+	}
+
+	return 0, false
+}
+
+func (c *explainContext) evalMapKey(expr ast.Expr, keyType reflect.Type) (reflect.Value, bool) {
+	var key reflect.Value
+
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		switch lit.Kind {
+		case token.STRING:
+			s, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return reflect.Value{}, false
+			}
+			key = reflect.ValueOf(s)
+		case token.INT:
+			i, err := strconv.Atoi(lit.Value)
+			if err != nil {
+				return reflect.Value{}, false
+			}
+			key = reflect.ValueOf(i)
+		default:
+			return reflect.Value{}, false
+		}
+	} else {
+		v, ok := c.eval(expr)
+		if !ok || !v.v.IsValid() {
+			return reflect.Value{}, false
+		}
+		key = v.v
+	}
+
+	if !key.Type().ConvertibleTo(keyType) || (key.Kind() == reflect.String) != (keyType.Kind() == reflect.String) {
+		return reflect.Value{}, false
+	}
+
+	return key.Convert(keyType), true
+}
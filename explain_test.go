@@ -0,0 +1,140 @@
+package expect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+type explainTestUser struct {
+	Name   string
+	Roles  []string
+	Labels map[string]int
+}
+
+func equalTo[T comparable](got, want T) Expectation {
+	return WithArguments(ExpectFunc(func(t TB) {
+		if got != want {
+			ReportValues(t, got, want)
+			t.Errorf("values are not equal")
+		}
+	}), got, want)
+}
+
+func notNil(got any) Expectation {
+	return WithArguments(ExpectFunc(func(t TB) {
+		if got == nil {
+			t.Errorf("expected value not to be nil")
+		}
+	}), got)
+}
+
+func TestExplain(t *testing.T) {
+	user := &explainTestUser{
+		Name:   "Alice",
+		Roles:  []string{"admin", "dev"},
+		Labels: map[string]int{"team": 7},
+	}
+
+	var tb testhelper.TB
+
+	Explain(&tb).That(
+		notNil(user),
+		equalTo(len(user.Roles), 3),
+		equalTo(user.Roles[1], "ops"),
+		equalTo(user.Labels["team"], 8),
+	)
+	Explain(&tb).That(equalTo(len(user.Roles), 3))
+	Explain(&tb).That(Fail)
+	WithMessage(&tb, "prefix").Explain().That(equalTo(user.Name, "Bob"))
+
+	want := testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"equalTo(len(user.Roles), 3): values are not equal",
+			"equalTo(len(user.Roles), 3)\n" +
+				"├── len(user.Roles) = 2\n" +
+				"│   └── user.Roles = [admin dev]\n" +
+				"│       └── user = &{Name:Alice Roles:[admin dev] Labels:map[team:7]}\n" +
+				"├── got = 2\n" +
+				"└── want = 3",
+			"equalTo(user.Roles[1], \"ops\"): values are not equal",
+			"equalTo(user.Roles[1], \"ops\")\n" +
+				"├── user.Roles[1] = \"dev\"\n" +
+				"│   └── user.Roles = [admin dev]\n" +
+				"│       └── user = &{Name:Alice Roles:[admin dev] Labels:map[team:7]}\n" +
+				"├── got = \"dev\"\n" +
+				"└── want = \"ops\"",
+			"equalTo(user.Labels[\"team\"], 8): values are not equal",
+			"equalTo(user.Labels[\"team\"], 8)\n" +
+				"├── user.Labels[\"team\"] = 7\n" +
+				"│   └── user.Labels = map[team:7]\n" +
+				"│       └── user = &{Name:Alice Roles:[admin dev] Labels:map[team:7]}\n" +
+				"├── got = 7\n" +
+				"└── want = 8",
+			"equalTo(len(user.Roles), 3): values are not equal",
+			"equalTo(len(user.Roles), 3)\n" +
+				"├── len(user.Roles) = 2\n" +
+				"├── got = 2\n" +
+				"└── want = 3",
+			"Fail: test failed",
+			"prefix: equalTo(user.Name, \"Bob\"): values are not equal",
+			"prefix: equalTo(user.Name, \"Bob\")\n" +
+				"├── user.Name = \"Alice\"\n" +
+				"├── got = \"Alice\"\n" +
+				"└── want = \"Bob\"",
+		},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestExplain_functionArguments(t *testing.T) {
+	var tb testhelper.TB
+
+	roles := []string{"dev", "admin"}
+	sorted := func(got []string, less func(a, b string) bool) Expectation {
+		return WithArguments(ExpectFunc(func(t TB) {
+			t.Errorf("not sorted")
+		}), got, less)
+	}
+
+	Explain(&tb).That(sorted(roles, func(a, b string) bool { return a < b }))
+
+	want := testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"sorted(roles, func(a, b string) bool { return a < b }): not sorted",
+			"sorted(roles, func(a, b string) bool { return a < b })\n└── roles = [dev admin]",
+		},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestExplain_fatal(t *testing.T) {
+	var tb testhelper.TB
+
+	n := 2
+	Explain(&tb).That(FailNow(equalTo(n, 3)), equalTo(n, 4))
+
+	want := testhelper.TB{
+		ErrFlag:   true,
+		FatalFlag: true,
+		Logs: []string{
+			"FailNow(equalTo(n, 3))\n├── equalTo(n, 3)\n│   └── n = 2\n├── got = 2\n└── want = 3",
+			"FailNow(equalTo(n, 3)): values are not equal",
+			"equalTo(n, 4): values are not equal",
+			"equalTo(n, 4)\n├── n = 2\n├── got = 2\n└── want = 4",
+		},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
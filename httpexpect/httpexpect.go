@@ -0,0 +1,218 @@
+// Package httpexpect provides a small harness to test HTTP handlers using the expectations defined in package
+// is. Requests are either served in-process by an http.Handler or sent to a httptest.Server. Cookies set by
+// responses are sent with subsequent requests. Failures are prefixed with the request's method and path and
+// the request/response exchange is logged only when an expectation fails.
+package httpexpect
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/failtrack"
+	"github.com/halimath/expect/is"
+)
+
+// inProcessBaseURL is the base URL used for requests served in-process. It
+// matches the host used by httptest.NewRequest.
+const inProcessBaseURL = "http://example.com"
+
+// Client sends requests to a handler or server and runs expectations on the
+// responses.
+type Client struct {
+	t       expect.TB
+	handler http.Handler
+	client  *http.Client
+	baseURL string
+	jar     http.CookieJar
+}
+
+// New creates a Client that serves all requests in-process by calling handler.
+func New(t expect.TB, handler http.Handler) *Client {
+	t.Helper()
+
+	return &Client{
+		t:       t,
+		handler: handler,
+		baseURL: inProcessBaseURL,
+		jar:     newCookieJar(t),
+	}
+}
+
+// NewServer creates a Client that sends all requests to server using the server's client.
+func NewServer(t expect.TB, server *httptest.Server) *Client {
+	t.Helper()
+
+	jar := newCookieJar(t)
+	client := *server.Client()
+	client.Jar = jar
+
+	return &Client{
+		t:       t,
+		client:  &client,
+		baseURL: server.URL,
+		jar:     jar,
+	}
+}
+
+func newCookieJar(t expect.TB) http.CookieJar {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("failed to create cookie jar: %v", err)
+	}
+
+	return jar
+}
+
+// Request creates a new request using the given method and path. path may contain a query string.
+func (c *Client) Request(method, path string) *Request {
+	return &Request{
+		client: c,
+		method: method,
+		path:   path,
+		header: make(http.Header),
+	}
+}
+
+// GET creates a new GET request for path.
+func (c *Client) GET(path string) *Request { return c.Request(http.MethodGet, path) }
+
+// HEAD creates a new HEAD request for path.
+func (c *Client) HEAD(path string) *Request { return c.Request(http.MethodHead, path) }
+
+// POST creates a new POST request for path.
+func (c *Client) POST(path string) *Request { return c.Request(http.MethodPost, path) }
+
+// PUT creates a new PUT request for path.
+func (c *Client) PUT(path string) *Request { return c.Request(http.MethodPut, path) }
+
+// PATCH creates a new PATCH request for path.
+func (c *Client) PATCH(path string) *Request { return c.Request(http.MethodPatch, path) }
+
+// DELETE creates a new DELETE request for path.
+func (c *Client) DELETE(path string) *Request { return c.Request(http.MethodDelete, path) }
+
+// Request defines a single request to be sent by a Client. Use the With... methods to customize the request
+// and Expect to send it.
+type Request struct {
+	client *Client
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+// WithHeader adds the header name with value to r.
+func (r *Request) WithHeader(name, value string) *Request {
+	r.header.Add(name, value)
+	return r
+}
+
+// WithBody sets r's body and the Content-Type header.
+func (r *Request) WithBody(contentType string, body []byte) *Request {
+	r.header.Set("Content-Type", contentType)
+	r.body = body
+	return r
+}
+
+// WithJSON sets r's body to the JSON document doc and the Content-Type header to application/json.
+func (r *Request) WithJSON(doc string) *Request {
+	return r.WithBody("application/json", []byte(doc))
+}
+
+// Expect sends r and runs all expectations on the response. All failures are prefixed with r's method and
+// path. If any expectation fails, the request followed by the response is logged. Expect returns the
+// response with a body that can be read again or nil if the request could not be sent.
+func (r *Request) Expect(expectations ...is.HTTPResponseExpectation) *http.Response {
+	r.client.t.Helper()
+
+	e := expect.WithMessage(r.client.t, "%s %s", r.method, r.path)
+
+	resp, dump, err := r.send()
+	if err != nil {
+		e.That(expect.ExpectFunc(func(t expect.TB) {
+			t.Helper()
+			t.Errorf("failed to send request: %v", err)
+		}))
+		return nil
+	}
+
+	e.That(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		tracker := failtrack.Wrap(t, func() {
+			t.Helper()
+			t.Logf("request:\n%s", dump)
+		})
+		is.HTTPResponse(resp, expectations...).Expect(tracker)
+	}))
+
+	return resp
+}
+
+// send sends r either in-process or to the server. It returns the response
+// as well as a dump of the request as it has been sent.
+func (r *Request) send() (*http.Response, string, error) {
+	u, err := url.Parse(r.client.baseURL + r.path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var req *http.Request
+	if r.client.handler == nil {
+		req, err = http.NewRequest(r.method, u.String(), bytes.NewReader(r.body))
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		req = httptest.NewRequest(r.method, r.path, bytes.NewReader(r.body))
+	}
+	req.Header = r.header.Clone()
+
+	cookies := r.client.jar.Cookies(u)
+	dump := dumpRequest(req, r.body, cookies)
+
+	if r.client.handler == nil {
+		resp, err := r.client.client.Do(req)
+		return resp, dump, err
+	}
+
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	rec := httptest.NewRecorder()
+	r.client.handler.ServeHTTP(rec, req)
+
+	resp := rec.Result()
+	resp.Request = req
+	r.client.jar.SetCookies(u, resp.Cookies())
+
+	return resp, dump, nil
+}
+
+// dumpRequest formats req in the style of httputil.DumpRequest including
+// cookies which are added when req is sent.
+func dumpRequest(req *http.Request, body []byte, cookies []*http.Cookie) string {
+	r := req.Clone(req.Context())
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	for _, c := range cookies {
+		r.AddCookie(c)
+	}
+
+	d, err := httputil.DumpRequest(r, true)
+	if err != nil {
+		return fmt.Sprintf("<failed to dump request: %v>", err)
+	}
+
+	return strings.ReplaceAll(string(d), "\r\n", "\n")
+}
@@ -0,0 +1,128 @@
+package httpexpect
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+	"github.com/halimath/expect/is"
+)
+
+func newHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "alice", Path: "/"})
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie("session")
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"name":%q,"lang":%q}`, c.Value, r.Header.Get("Accept-Language"))
+	})
+
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		io.Copy(w, r.Body)
+	})
+
+	return mux
+}
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(newHandler())
+	defer server.Close()
+
+	for name, newClient := range map[string]func(expect.TB) *Client{
+		"in-process": func(t expect.TB) *Client { return New(t, newHandler()) },
+		"server":     func(t expect.TB) *Client { return NewServer(t, server) },
+	} {
+		newClient := newClient
+
+		t.Run(name, func(t *testing.T) {
+			var tb testhelper.TB
+			c := newClient(&tb)
+
+			c.GET("/me").Expect(is.Status(http.StatusUnauthorized))
+			c.POST("/login").Expect(is.Status(http.StatusNoContent), is.Cookie("session", nil))
+
+			resp := c.GET("/me").
+				WithHeader("Accept-Language", "de").
+				Expect(
+					is.Status(http.StatusOK),
+					is.BodyJSON(`{"name": "alice", "lang": "de"}`),
+				)
+
+			c.PUT("/echo").WithJSON(`{"id":1}`).Expect(
+				is.ContentType("application/json", nil),
+				is.BodyJSONAt("/id", is.JSONNodeEqualTo("1")),
+			)
+
+			if !reflect.DeepEqual(tb, testhelper.TB{}) {
+				t.Errorf("not expected: %#v", tb)
+			}
+
+			if resp == nil {
+				t.Fatal("expected response")
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != `{"name":"alice","lang":"de"}` {
+				t.Errorf("not expected: %q", body)
+			}
+		})
+	}
+}
+
+func TestClient_failure(t *testing.T) {
+	var tb testhelper.TB
+
+	c := New(&tb, newHandler())
+	c.POST("/login").Expect(is.Status(http.StatusNoContent))
+	c.POST("/echo").WithBody("text/plain", []byte("hello")).Expect(is.Status(http.StatusOK))
+	c.POST("/echo").WithBody("text/plain", []byte("hello")).Expect(
+		is.Status(http.StatusCreated),
+		is.Body(func(body string) expect.Expectation { return is.EqualTo(body, "world") }),
+	)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"POST /echo: request:\nPOST /echo HTTP/1.1\nHost: example.com\nContent-Type: text/plain\nCookie: session=alice\n\nhello",
+			"POST /echo: response:\nHTTP/1.1 200 OK\nContent-Length: 5\nContent-Type: text/plain\n\nhello",
+			"POST /echo: expected status 201 Created but got 200 OK",
+			"POST /echo: body: values are not equal\nwant: world\ngot:  hello",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestClient_sendFailure(t *testing.T) {
+	var tb testhelper.TB
+
+	server := httptest.NewServer(newHandler())
+	server.Close()
+
+	if resp := NewServer(&tb, server).GET("/me").Expect(is.Status(http.StatusOK)); resp != nil {
+		t.Errorf("expected no response but got %v", resp)
+	}
+
+	if !tb.ErrFlag || len(tb.Logs) != 1 {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
@@ -0,0 +1,78 @@
+// Package failtrack implements an expect.TB that logs additional context, such as a dump of an HTTP response,
+// when a failure is reported. The context is logged before the first failure so that it is logged even if
+// the failure stops the test's goroutine, i.e. when errors are turned into fatal failures (see
+// expect.FailNow).
+package failtrack
+
+import "github.com/halimath/expect"
+
+// TB is an expect.TB that calls a list of report functions once before the first failure is reported.
+type TB struct {
+	expect.TB
+	reports  []func()
+	reported bool
+}
+
+// Wrap returns a TB that calls report before the first failure is reported to t. If t is a *TB itself,
+// report is added to t and t is returned. Thus, nested reports are called in the order they have been
+// registered with the outermost report being called first.
+func Wrap(t expect.TB, report func()) *TB {
+	if f, ok := t.(*TB); ok {
+		f.reports = append(f.reports, report)
+		return f
+	}
+
+	return &TB{TB: t, reports: []func(){report}}
+}
+
+// Unwrap returns the wrapped TB.
+func (f *TB) Unwrap() expect.TB { return f.TB }
+
+func (f *TB) failed() {
+	f.TB.Helper()
+
+	if f.reported {
+		return
+	}
+	f.reported = true
+
+	for _, report := range f.reports {
+		report()
+	}
+}
+
+func (f *TB) Error(args ...any) {
+	f.TB.Helper()
+	f.failed()
+	f.TB.Error(args...)
+}
+
+func (f *TB) Errorf(format string, args ...any) {
+	f.TB.Helper()
+	f.failed()
+	f.TB.Errorf(format, args...)
+}
+
+func (f *TB) Fail() {
+	f.TB.Helper()
+	f.failed()
+	f.TB.Fail()
+}
+
+func (f *TB) Fatal(args ...any) {
+	f.TB.Helper()
+	f.failed()
+	f.TB.Fatal(args...)
+}
+
+func (f *TB) Fatalf(format string, args ...any) {
+	f.TB.Helper()
+	f.failed()
+	f.TB.Fatalf(format, args...)
+}
+
+func (f *TB) FailNow() {
+	f.TB.Helper()
+	f.failed()
+	f.TB.FailNow()
+}
@@ -0,0 +1,216 @@
+// Package grapheme implements a small segmenter splitting strings into user-perceived characters (extended
+// grapheme clusters) following the rules given in Unicode Standard Annex #29. The implementation covers the
+// rules relevant for common text: CR LF sequences, control characters, combining marks, Hangul syllables,
+// regional indicator pairs (flags) and emoji sequences joined by zero width joiners. Character properties are
+// approximated using the unicode package and a compact table of pictographic ranges.
+package grapheme
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Count returns the number of grapheme clusters in s.
+func Count(s string) int {
+	n := 0
+	for len(s) > 0 {
+		_, s = Next(s)
+		n++
+	}
+	return n
+}
+
+// Split splits s into grapheme clusters.
+func Split(s string) []string {
+	var res []string
+	for len(s) > 0 {
+		var c string
+		c, s = Next(s)
+		res = append(res, c)
+	}
+	return res
+}
+
+// Next returns the first grapheme cluster of s and the remainder of s.
+func Next(s string) (cluster, rest string) {
+	if len(s) == 0 {
+		return "", ""
+	}
+
+	prev, size := utf8.DecodeRuneInString(s)
+	prevProp := propertyOf(prev)
+	pos := size
+
+	// riCount counts the number of consecutive regional indicators.
+	riCount := 0
+	if prevProp == regionalIndicator {
+		riCount = 1
+	}
+
+	// pictSeq is set when the sequence so far is an extended pictographic
+	// followed by any number of extends (GB11).
+	pictSeq := prevProp == extendedPictographic
+
+	for pos < len(s) {
+		r, size := utf8.DecodeRuneInString(s[pos:])
+		prop := propertyOf(r)
+
+		if isBoundary(prevProp, prop, riCount, pictSeq) {
+			break
+		}
+
+		switch {
+		case prop == regionalIndicator:
+			riCount++
+		case prop == extendedPictographic:
+			pictSeq = true
+		case prop == extend:
+			// keep pictSeq
+		case prop == zwj:
+			// keep pictSeq; checked in isBoundary
+		default:
+			pictSeq = false
+		}
+
+		prevProp = prop
+		pos += size
+	}
+
+	return s[:pos], s[pos:]
+}
+
+func isBoundary(prev, next property, riCount int, pictSeq bool) bool {
+	switch {
+	// GB3
+	case prev == cr && next == lf:
+		return false
+	// GB4, GB5
+	case prev == control || prev == cr || prev == lf,
+		next == control || next == cr || next == lf:
+		return true
+	// GB6
+	case prev == hangulL && (next == hangulL || next == hangulV || next == hangulLV || next == hangulLVT):
+		return false
+	// GB7
+	case (prev == hangulLV || prev == hangulV) && (next == hangulV || next == hangulT):
+		return false
+	// GB8
+	case (prev == hangulLVT || prev == hangulT) && next == hangulT:
+		return false
+	// GB9, GB9a
+	case next == extend || next == zwj || next == spacingMark:
+		return false
+	// GB9b
+	case prev == prepend:
+		return false
+	// GB11
+	case prev == zwj && next == extendedPictographic && pictSeq:
+		return false
+	// GB12, GB13
+	case prev == regionalIndicator && next == regionalIndicator:
+		return riCount%2 == 0
+	// GB999
+	default:
+		return true
+	}
+}
+
+type property int
+
+const (
+	other property = iota
+	cr
+	lf
+	control
+	extend
+	zwj
+	regionalIndicator
+	prepend
+	spacingMark
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+	extendedPictographic
+)
+
+func propertyOf(r rune) property {
+	switch {
+	case r == '\r':
+		return cr
+	case r == '\n':
+		return lf
+	case r == 0x200D:
+		return zwj
+	case r == 0x200C:
+		return extend
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return regionalIndicator
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		// Emoji modifiers (skin tones)
+		return extend
+	case r >= 0xE0020 && r <= 0xE007F:
+		// Tags used in emoji tag sequences
+		return extend
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return extend
+	case unicode.Is(unicode.Mc, r):
+		return spacingMark
+	case r == 0x0600 || r == 0x0601 || r == 0x0602 || r == 0x0603 || r == 0x0604 || r == 0x0605 || r == 0x06DD || r == 0x070F || r == 0x08E2 || r == 0x110BD:
+		return prepend
+	case unicode.In(r, unicode.Cc, unicode.Zl, unicode.Zp) || (unicode.Is(unicode.Cf, r) && r != 0x200D && r != 0x200C):
+		return control
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return hangulL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return hangulV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return hangulT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	case isExtendedPictographic(r):
+		return extendedPictographic
+	default:
+		return other
+	}
+}
+
+// pictographicRanges approximates the Extended_Pictographic property.
+var pictographicRanges = [][2]rune{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x231A, 0x231B}, {0x2328, 0x2328}, {0x2388, 0x2388}, {0x23CF, 0x23CF},
+	{0x23E9, 0x23F3}, {0x23F8, 0x23FA}, {0x24C2, 0x24C2}, {0x25AA, 0x25AB},
+	{0x25B6, 0x25B6}, {0x25C0, 0x25C0}, {0x25FB, 0x25FE}, {0x2600, 0x27BF},
+	{0x2934, 0x2935}, {0x2B05, 0x2B07}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50},
+	{0x2B55, 0x2B55}, {0x3030, 0x3030}, {0x303D, 0x303D}, {0x3297, 0x3297},
+	{0x3299, 0x3299}, {0x1F000, 0x1F0FF}, {0x1F10D, 0x1F10F}, {0x1F12F, 0x1F12F},
+	{0x1F16C, 0x1F171}, {0x1F17E, 0x1F17F}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A},
+	{0x1F1AD, 0x1F1E5}, {0x1F201, 0x1F20F}, {0x1F21A, 0x1F21A}, {0x1F22F, 0x1F22F},
+	{0x1F232, 0x1F23A}, {0x1F23C, 0x1F23F}, {0x1F249, 0x1F3FA}, {0x1F400, 0x1F53D},
+	{0x1F546, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F774, 0x1F77F}, {0x1F7D5, 0x1F7FF},
+	{0x1F80C, 0x1F80F}, {0x1F848, 0x1F84F}, {0x1F85A, 0x1F85F}, {0x1F888, 0x1F88F},
+	{0x1F8AE, 0x1F8FF}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1FAFF},
+	{0x1FC00, 0x1FFFD},
+}
+
+func isExtendedPictographic(r rune) bool {
+	if r < pictographicRanges[0][0] {
+		return false
+	}
+
+	for _, rg := range pictographicRanges {
+		if r < rg[0] {
+			return false
+		}
+		if r <= rg[1] {
+			return true
+		}
+	}
+
+	return false
+}
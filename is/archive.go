@@ -0,0 +1,161 @@
+package is
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"testing/fstest"
+
+	"github.com/halimath/expect"
+)
+
+// ZipContaining expects the zip archive read from r to contain all entries given in entries. The archive may
+// contain additional entries. Entries are compared the same way FSEqualTo compares files; use opts to compare
+// modes and modification times as well.
+func ZipContaining(r io.Reader, entries fstest.MapFS, opts ...FSOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		got, err := readZip(r)
+		if err != nil {
+			t.Errorf("failed to read zip archive: %v", err)
+			return
+		}
+
+		o := newFSOptions(opts)
+		o.ignoreExtra = true
+		diffFS(t, got, entries, o)
+	}), r, entries)
+}
+
+// TarEqualTo expects the tar archive read from r to contain exactly the files given in want. Archives
+// compressed with gzip are detected and decompressed automatically. Differences are reported the same way
+// FSEqualTo reports them; use opts to compare modes and modification times as well.
+func TarEqualTo(r io.Reader, want fstest.MapFS, opts ...FSOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		got, err := readTar(r)
+		if err != nil {
+			t.Errorf("failed to read tar archive: %v", err)
+			return
+		}
+
+		diffFS(t, got, want, newFSOptions(opts))
+	}), r, want)
+}
+
+// readZip reads all regular file entries from the zip archive read from r.
+func readZip(r io.Reader) (fstest.MapFS, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(fstest.MapFS, len(zr.File))
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		name, err := archiveEntryName(f.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+
+		files[name] = &fstest.MapFile{
+			Data:    content,
+			Mode:    f.Mode(),
+			ModTime: f.Modified,
+		}
+	}
+
+	return files, nil
+}
+
+// gzipMagic contains the bytes every gzip stream starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// readTar reads all regular file entries from the tar archive read from r
+// decompressing it if it is compressed with gzip.
+func readTar(r io.Reader) (fstest.MapFS, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	} else {
+		r = br
+	}
+
+	tr := tar.NewReader(r)
+	files := make(fstest.MapFS)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		info := hdr.FileInfo()
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		name, err := archiveEntryName(hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", hdr.Name, err)
+		}
+
+		files[name] = &fstest.MapFile{
+			Data:    content,
+			Mode:    info.Mode(),
+			ModTime: hdr.ModTime,
+		}
+	}
+}
+
+// archiveEntryName converts the name of an archive entry into a name valid
+// for use with fs.FS.
+func archiveEntryName(name string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean(name), "/")
+	if !fs.ValidPath(cleaned) || cleaned == "." {
+		return "", fmt.Errorf("invalid entry name %q", name)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,175 @@
+package is
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+var archiveModTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type archiveEntry struct {
+	name string
+	mode fs.FileMode
+	data string
+}
+
+func buildZip(t *testing.T, entries ...archiveEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate, Modified: archiveModTime}
+		hdr.SetMode(e.mode)
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func buildTar(t *testing.T, entries ...archiveEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: int64(e.mode.Perm()), ModTime: archiveModTime}
+		if e.mode.IsDir() {
+			hdr.Typeflag = tar.TypeDir
+		} else {
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = int64(len(e.data))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestZipContaining(t *testing.T) {
+	var tb testhelper.TB
+
+	archive := buildZip(t,
+		archiveEntry{name: "bin/", mode: fs.ModeDir | 0o755},
+		archiveEntry{name: "bin/tool", mode: 0o755, data: "#!/bin/sh"},
+		archiveEntry{name: "README.md", mode: 0o644, data: "# Tool\n"},
+		archiveEntry{name: "LICENSE", mode: 0o644, data: "MIT"},
+	)
+
+	ZipContaining(bytes.NewReader(archive), fstest.MapFS{
+		"bin/tool":  {Data: []byte("#!/bin/sh"), Mode: 0o755, ModTime: archiveModTime},
+		"README.md": {Data: []byte("# Tool\n"), Mode: 0o644, ModTime: archiveModTime},
+	}, CompareModes(true), CompareModTimes(true)).Expect(&tb)
+
+	ZipContaining(bytes.NewReader(archive), fstest.MapFS{
+		"bin/tool":  {Data: []byte("#!/bin/sh"), Mode: 0o644},
+		"README.md": {Data: []byte("# Tool\n"), ModTime: archiveModTime.Add(time.Hour)},
+		"CHANGELOG": {Data: []byte("")},
+	}, CompareModes(true), CompareModTimes(true), ExcludeFiles{"README.md"}).Expect(&tb)
+
+	ZipContaining(strings.NewReader("not a zip"), fstest.MapFS{}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"file trees differ:\nmissing files:\n  CHANGELOG",
+			"file bin/tool: expected mode -rw-r--r-- but got -rwxr-xr-x",
+			"file bin/tool: expected modification time 0001-01-01T00:00:00Z but got 2024-01-02T03:04:05Z",
+			"failed to read zip archive: zip: not a valid zip file",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestTarEqualTo(t *testing.T) {
+	archive := buildTar(t,
+		archiveEntry{name: "./bin/", mode: fs.ModeDir | 0o755},
+		archiveEntry{name: "./bin/tool", mode: 0o755, data: "#!/bin/sh"},
+		archiveEntry{name: "./README.md", mode: 0o644, data: "# Tool\n"},
+	)
+
+	want := fstest.MapFS{
+		"bin/tool":  {Data: []byte("#!/bin/sh"), Mode: 0o755, ModTime: archiveModTime},
+		"README.md": {Data: []byte("# Tool\n"), Mode: 0o644, ModTime: archiveModTime},
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		var tb testhelper.TB
+
+		TarEqualTo(bytes.NewReader(archive), want, CompareModes(true), CompareModTimes(true)).Expect(&tb)
+		TarEqualTo(bytes.NewReader(gzipped(t, archive)), want, CompareModes(true)).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		var tb testhelper.TB
+
+		TarEqualTo(bytes.NewReader(gzipped(t, archive)), fstest.MapFS{
+			"bin/tool":  {Data: []byte("#!/bin/bash"), Mode: 0o755},
+			"CHANGELOG": {Data: []byte("")},
+		}, CompareModes(true)).Expect(&tb)
+
+		TarEqualTo(bytes.NewReader(gzipMagic), want).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{
+			ErrFlag: true,
+			Logs: []string{
+				"file trees differ:\nmissing files:\n  CHANGELOG\nextra files:\n  README.md",
+				"file bin/tool: at line 0: wanted\n\"#!/bin/bash\"\nbut got\n\"#!/bin/sh\"",
+				"failed to read tar archive: unexpected EOF",
+			},
+		}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+}
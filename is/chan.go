@@ -0,0 +1,134 @@
+package is
+
+import (
+	"time"
+
+	"github.com/halimath/expect"
+)
+
+// Chan is a constraint for channel types values can be received from.
+type Chan[T any] interface {
+	~chan T | ~<-chan T
+}
+
+// ChanReceiving expects to receive a value from ch within timeout and runs the expectation created by calling
+// f with the received value. f may be nil in which case only the reception of a value is checked.
+func ChanReceiving[C Chan[T], T any](ch C, timeout time.Duration, f func(T) expect.Expectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				t.Errorf("expected to receive a value within %s but channel has been closed", timeout)
+				return
+			}
+			if f != nil {
+				f(v).Expect(t)
+			}
+
+		case <-timer.C:
+			t.Errorf("expected to receive a value within %s but received none", timeout)
+		}
+	}), ch, timeout, f)
+}
+
+// ChanReceivingInOrder expects to receive all of values from ch in order within timeout. Values are compared
+// using the same algorithm as DeepEqualTo. Receiving stops at the first value not being equal to the wanted
+// one.
+func ChanReceivingInOrder[C Chan[T], T any](ch C, timeout time.Duration, values ...T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		received := make([]T, 0, len(values))
+
+		for i, want := range values {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					t.Errorf("expected to receive %d values within %s but channel has been closed after receiving %d: %v", len(values), timeout, len(received), received)
+					return
+				}
+				received = append(received, v)
+
+				if d := deepEquals(want, v); d != nil {
+					t.Errorf("value %d received from channel differs from the wanted one (received %d: %v):%s", i, len(received), received, d)
+					return
+				}
+
+			case <-timer.C:
+				t.Errorf("expected to receive %d values within %s but received %d before the timeout: %v", len(values), timeout, len(received), received)
+				return
+			}
+		}
+	}), ch, timeout)
+}
+
+// ChanClosed expects ch to be closed within timeout. Any value received from ch before it gets closed is
+// reported as a failure.
+func ChanClosed[C Chan[T], T any](ch C, timeout time.Duration) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		var received []T
+
+		for {
+			select {
+			case v, ok := <-ch:
+				if ok {
+					received = append(received, v)
+					continue
+				}
+
+				if len(received) > 0 {
+					t.Errorf("expected channel to be closed but received %d values before it got closed: %v", len(received), received)
+				}
+				return
+
+			case <-timer.C:
+				t.Errorf("expected channel to be closed within %s but it is still open after receiving %d values: %v", timeout, len(received), received)
+				return
+			}
+		}
+	}), ch, timeout)
+}
+
+// ChanEmpty expects ch to have no buffered values. ChanEmpty does not receive any value from ch. Unbuffered
+// channels are always empty.
+func ChanEmpty[C Chan[T], T any](ch C) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if l := len(ch); l > 0 {
+			t.Errorf("expected channel to be empty but it contains %d buffered values", l)
+		}
+	}), ch)
+}
+
+// ChanNotReceiving expects not to receive any value from ch for duration. A closed channel does not deliver
+// any value and thus satisfies ChanNotReceiving.
+func ChanNotReceiving[C Chan[T], T any](ch C, duration time.Duration) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+
+		select {
+		case v, ok := <-ch:
+			if ok {
+				t.Errorf("expected not to receive any value for %s but received %v", duration, v)
+			}
+		case <-timer.C:
+		}
+	}), ch, duration)
+}
@@ -0,0 +1,131 @@
+package is
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestChanReceiving(t *testing.T) {
+	var tb testhelper.TB
+
+	ch := make(chan int, 1)
+	ch <- 2
+	ChanReceiving(ch, time.Millisecond, func(v int) expect.Expectation { return EqualTo(v, 2) }).Expect(&tb)
+
+	ch <- 3
+	var recv <-chan int = ch
+	ChanReceiving(recv, time.Millisecond, func(v int) expect.Expectation { return EqualTo(v, 2) }).Expect(&tb)
+
+	ChanReceiving(ch, time.Millisecond, nil).Expect(&tb)
+
+	close(ch)
+	ChanReceiving(ch, time.Millisecond, nil).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"values are not equal\nwant: 2\ngot:  3",
+			"expected to receive a value within 1ms but received none",
+			"expected to receive a value within 1ms but channel has been closed",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestChanReceivingInOrder(t *testing.T) {
+	var tb testhelper.TB
+
+	ch := make(chan string, 3)
+	ch <- "a"
+	ch <- "b"
+	ChanReceivingInOrder(ch, time.Millisecond, "a", "b").Expect(&tb)
+
+	ch <- "a"
+	ch <- "b"
+	ChanReceivingInOrder(ch, time.Millisecond, "a", "b", "c").Expect(&tb)
+
+	ch <- "a"
+	ch <- "c"
+	ChanReceivingInOrder(ch, time.Millisecond, "a", "b").Expect(&tb)
+
+	ch <- "a"
+	close(ch)
+	ChanReceivingInOrder(ch, time.Millisecond, "a", "b").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected to receive 3 values within 1ms but received 2 before the timeout: [a b]",
+			"value 1 received from channel differs from the wanted one (received 2: [a c]):\n  want: b\n   got: c",
+			"expected to receive 2 values within 1ms but channel has been closed after receiving 1: [a]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestChanClosed(t *testing.T) {
+	var tb testhelper.TB
+
+	ch := make(chan int, 2)
+	ChanClosed(ch, time.Millisecond).Expect(&tb)
+
+	ch <- 1
+	ch <- 2
+	close(ch)
+	ChanClosed(ch, time.Millisecond).Expect(&tb)
+	ChanClosed(ch, time.Millisecond).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected channel to be closed within 1ms but it is still open after receiving 0 values: []",
+			"expected channel to be closed but received 2 values before it got closed: [1 2]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestChanEmpty(t *testing.T) {
+	var tb testhelper.TB
+
+	ch := make(chan int, 2)
+	ChanEmpty(ch).Expect(&tb)
+	ch <- 1
+	ChanEmpty(ch).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected channel to be empty but it contains 1 buffered values",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestChanNotReceiving(t *testing.T) {
+	var tb testhelper.TB
+
+	ch := make(chan int, 1)
+	ChanNotReceiving(ch, time.Millisecond).Expect(&tb)
+	ch <- 1
+	ChanNotReceiving(ch, time.Millisecond).Expect(&tb)
+	close(ch)
+	ChanNotReceiving(ch, time.Millisecond).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected not to receive any value for 1ms but received 1",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
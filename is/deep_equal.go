@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/halimath/expect"
 	"github.com/halimath/expect/internal/set"
@@ -61,16 +62,28 @@ type ExcludeFields []string
 
 func (ExcludeFields) deepEqualOpt() {}
 
+// TimeTolerance is a DeepEqualOpt that makes DeepEqualTo compare time.Time
+// values as instants in time (using time.Time.Equal) instead of comparing
+// their fields. Thus, neither the location nor the monotonic clock reading
+// are taken into account. Two values are considered equal if they differ by
+// no more than the given duration; use TimeTolerance(0) to compare instants
+// exactly. time.Time values held in unexported struct fields are always
+// compared field by field.
+type TimeTolerance time.Duration
+
+func (TimeTolerance) deepEqualOpt() {}
+
 // IsDeepEqualTo asserts that given and wanted value are deeply equal by using reflection to inspect and dive
 // into nested structures.
 func DeepEqualTo[T any](got, want T, opts ...DeepEqualOpt) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		if diff := deepEquals(want, got, opts...); diff != nil {
+			expect.ReportValues(t, got, want)
 			t.Errorf("values are not deeply equal:%s", diff)
 		}
-	})
+	}), got, want)
 }
 
 func deepEquals(want, got any, opts ...DeepEqualOpt) diff {
@@ -95,6 +108,9 @@ func deepEquals(want, got any, opts ...DeepEqualOpt) diff {
 			for _, t := range o {
 				ctx.excludedTypes[t] = struct{}{}
 			}
+		case TimeTolerance:
+			ctx.compareTimesAsInstants = true
+			ctx.timeTolerance = time.Duration(o)
 		case ExcludeFields:
 			for _, p := range o {
 				pat := strings.ReplaceAll(p, ".", "\\.")
@@ -119,6 +135,8 @@ func deepEquals(want, got any, opts ...DeepEqualOpt) diff {
 	return ctx.diff
 }
 
+var timeType = reflect.TypeOf(time.Time{})
+
 func determineDiff(ctx *diffContext, want, got reflect.Value) {
 	// If want has already been visited, determination ends here to not run into cycles.
 	if ctx.hasVisisted(want) {
@@ -161,6 +179,16 @@ func determineDiff(ctx *diffContext, want, got reflect.Value) {
 		return
 	}
 
+	// Compare times as instants if requested.
+	if ctx.compareTimesAsInstants && wantType == timeType && want.CanInterface() && got.CanInterface() {
+		w := want.Interface().(time.Time)
+		g := got.Interface().(time.Time)
+		if !timeWithin(g, w, ctx.timeTolerance) {
+			ctx.addDiff(formatTime(w), formatTime(g))
+		}
+		return
+	}
+
 	// Inspect the value's kinds.
 	wantKind := want.Kind()
 	gotKind := got.Kind()
@@ -393,6 +421,8 @@ type diffContext struct {
 	excludeUnexportedStructFields bool
 	excludedTypes                 map[reflect.Type]struct{}
 	excludedFields                []*regexp.Regexp
+	compareTimesAsInstants        bool
+	timeTolerance                 time.Duration
 
 	wantsSeen   set.Set[reflect.Value]
 	diff        diff
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/halimath/expect/internal/testhelper"
 )
@@ -213,3 +214,35 @@ func TestDeepEquals_excludeFields(t *testing.T) {
 		t.Errorf("expected no diff but got %#v", got)
 	}
 }
+
+func TestDeepEquals_timeTolerance(t *testing.T) {
+	type event struct {
+		Name string
+		At   time.Time
+		Next *time.Time
+	}
+
+	base := time.Now()
+	next := base.Add(time.Hour).In(time.FixedZone("CET", 3600))
+	nextUTC := base.Add(time.Hour + time.Millisecond).UTC()
+
+	want := event{Name: "a", At: base.Round(0), Next: &next}
+	got := event{Name: "a", At: base.UTC(), Next: &nextUTC}
+
+	if d := deepEquals(want, got, TimeTolerance(time.Second)); d != nil {
+		t.Errorf("unexpected diff: %v", d)
+	}
+
+	d := deepEquals(want, got, TimeTolerance(0))
+	if !reflect.DeepEqual(d, diff{{".Next", next.Format(time.RFC3339Nano), nextUTC.Format(time.RFC3339Nano)}}) {
+		t.Errorf("unexpected diff: %v", d)
+	}
+
+	if d := deepEquals(want, got); d == nil {
+		t.Errorf("expected a diff without TimeTolerance")
+	}
+
+	if d := deepEquals(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), base, TimeTolerance(time.Second)); d == nil {
+		t.Errorf("expected a diff for times further apart than time.Duration's range")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/halimath/expect"
 	"github.com/halimath/expect/internal/testhelper"
 )
 
@@ -20,3 +21,23 @@ func TestEqualTo(t *testing.T) {
 		t.Errorf("not expected: %#v", tb)
 	}
 }
+
+func TestEqualTo_explain(t *testing.T) {
+	var tb testhelper.TB
+
+	user := struct{ Roles []string }{Roles: []string{"admin", "dev"}}
+	expect.Explain(&tb).That(EqualTo(len(user.Roles), 3))
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"EqualTo(len(user.Roles), 3): values are not equal\nwant: 3\ngot:  2",
+			"EqualTo(len(user.Roles), 3)\n" +
+				"├── len(user.Roles) = 2\n" +
+				"├── got = 2\n" +
+				"└── want = 3",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
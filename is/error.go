@@ -2,6 +2,7 @@ package is
 
 import (
 	"errors"
+	"reflect"
 
 	"github.com/halimath/expect"
 )
@@ -15,7 +16,7 @@ func Error(got, target error) expect.Expectation {
 		return NoError(got)
 	}
 
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		if got == nil {
@@ -26,16 +27,24 @@ func Error(got, target error) expect.Expectation {
 		if !errors.Is(got, target) {
 			t.Errorf("expected an error with target %v but got %v", target, got)
 		}
-	})
+	}), got, target)
 }
 
-// NoError expects v to be nil.
+// NoError expects v to be nil. If v is a non-nil error holding a nil value of some concrete type (a typed
+// nil) the failure message explains this situation.
 func NoError(v error) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
-		if v != nil {
-			t.Errorf("expected no error but got %q", v)
+		if v == nil {
+			return
 		}
-	})
+
+		if isNilValue(reflect.ValueOf(v)) {
+			t.Errorf("expected no error but got %s", describeTypedNil(reflect.ValueOf(&v).Elem()))
+			return
+		}
+
+		t.Errorf("expected no error but got %q", v)
+	}), v)
 }
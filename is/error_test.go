@@ -44,3 +44,23 @@ func TestError(t *testing.T) {
 		t.Errorf("not expected: %#v", tm)
 	}
 }
+
+type typedNilError struct{}
+
+func (*typedNilError) Error() string { return "typed nil error" }
+
+func TestNoError_typedNil(t *testing.T) {
+	var tm testhelper.TB
+
+	var e *typedNilError
+	NoError(e).Expect(&tm)
+
+	if !reflect.DeepEqual(tm, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected no error but got a non-nil error holding a nil *is.typedNilError (typed nil); an interface value is only nil if both its type and value are nil",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tm)
+	}
+}
@@ -0,0 +1,388 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/halimath/expect"
+)
+
+// FileExists expects fsys to contain a regular file named name.
+func FileExists(fsys fs.FS, name string) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			t.Errorf("expected file %s to exist but got error: %v", name, err)
+			return
+		}
+
+		if !info.Mode().IsRegular() {
+			t.Errorf("expected %s to be a regular file but got mode %s", name, info.Mode())
+		}
+	}), fsys, name)
+}
+
+// DirExists expects fsys to contain a directory named name.
+func DirExists(fsys fs.FS, name string) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			t.Errorf("expected directory %s to exist but got error: %v", name, err)
+			return
+		}
+
+		if !info.IsDir() {
+			t.Errorf("expected %s to be a directory but got mode %s", name, info.Mode())
+		}
+	}), fsys, name)
+}
+
+// FileContent reads the file name from fsys and runs the expectation created by calling f with the file's
+// content. Failures are prefixed with the file's name.
+func FileContent(fsys fs.FS, name string, f func(content string) expect.Expectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			t.Errorf("failed to read file %s: %v", name, err)
+			return
+		}
+
+		expect.WithMessage(t, "file %s", name).That(f(string(data)))
+	}), fsys, name, f)
+}
+
+// FileMode expects the file (or directory) name in fsys to have mode want.
+func FileMode(fsys fs.FS, name string, want fs.FileMode) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		info, err := fs.Stat(fsys, name)
+		if err != nil {
+			t.Errorf("expected %s to exist but got error: %v", name, err)
+			return
+		}
+
+		if info.Mode() != want {
+			expect.ReportValues(t, info.Mode(), want)
+			t.Errorf("expected %s to have mode %s but got %s", name, want, info.Mode())
+		}
+	}), fsys, name, want)
+}
+
+// FSOpt defines an interface for types that can be used as options for
+// FSEqualTo.
+type FSOpt interface {
+	fsOpt()
+}
+
+// IncludeFiles is an FSOpt that restricts the comparison to files matching
+// any of the given glob patterns. Patterns use the syntax of path.Match.
+// Patterns containing a slash are matched against the file's full path, all
+// other patterns are matched against the file's base name.
+type IncludeFiles []string
+
+func (IncludeFiles) fsOpt() {}
+
+// ExcludeFiles is an FSOpt that excludes all files matching any of the given
+// glob patterns from the comparison. See IncludeFiles for the pattern
+// syntax.
+type ExcludeFiles []string
+
+func (ExcludeFiles) fsOpt() {}
+
+// CompareModes is an FSOpt that defines whether the modes of files are
+// compared in addition to their contents.
+type CompareModes bool
+
+func (CompareModes) fsOpt() {}
+
+// CompareModTimes is an FSOpt that defines whether the modification times of
+// files are compared in addition to their contents. Times are compared with
+// a precision of one second as most archive formats do not store more.
+type CompareModTimes bool
+
+func (CompareModTimes) fsOpt() {}
+
+// FSEqualTo expects the file trees got and want to contain the same files with equal contents. Failures list
+// missing and extra files. Differing text files are reported line by line (see EqualToStringByLines) while
+// differing binary files are reported with a hex dump of the first difference. Only regular files are
+// compared; use opts to restrict the files being compared or to compare modes and modification times.
+func FSEqualTo(got, want fs.FS, opts ...FSOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+		diffFS(t, got, want, newFSOptions(opts))
+	}), got, want)
+}
+
+type fsOptions struct {
+	include         []string
+	exclude         []string
+	compareModes    bool
+	compareModTimes bool
+	ignoreExtra     bool
+}
+
+func newFSOptions(opts []FSOpt) fsOptions {
+	var o fsOptions
+	for _, opt := range opts {
+		switch x := opt.(type) {
+		case IncludeFiles:
+			o.include = append(o.include, x...)
+		case ExcludeFiles:
+			o.exclude = append(o.exclude, x...)
+		case CompareModes:
+			o.compareModes = bool(x)
+		case CompareModTimes:
+			o.compareModTimes = bool(x)
+		}
+	}
+	return o
+}
+
+func (o fsOptions) selected(name string) bool {
+	if len(o.include) > 0 && !matchesAnyGlob(o.include, name) {
+		return false
+	}
+	return !matchesAnyGlob(o.exclude, name)
+}
+
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, p := range patterns {
+		subject := name
+		if !strings.Contains(p, "/") {
+			subject = path.Base(name)
+		}
+		if ok, _ := path.Match(p, subject); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// diffFS compares the trees got and want reporting all differences to t.
+func diffFS(t expect.TB, got, want fs.FS, opts fsOptions) {
+	t.Helper()
+
+	gotFiles, err := listFiles(got, opts)
+	if err != nil {
+		t.Errorf("failed to list got files: %v", err)
+		return
+	}
+
+	wantFiles, err := listFiles(want, opts)
+	if err != nil {
+		t.Errorf("failed to list want files: %v", err)
+		return
+	}
+
+	var missing, extra, common []string
+	for _, name := range wantFiles {
+		if contains(gotFiles, name) {
+			common = append(common, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if !opts.ignoreExtra {
+		for _, name := range gotFiles {
+			if !contains(wantFiles, name) {
+				extra = append(extra, name)
+			}
+		}
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		var b strings.Builder
+		b.WriteString("file trees differ:")
+		if len(missing) > 0 {
+			b.WriteString("\nmissing files:")
+			for _, name := range missing {
+				fmt.Fprintf(&b, "\n  %s", name)
+			}
+		}
+		if len(extra) > 0 {
+			b.WriteString("\nextra files:")
+			for _, name := range extra {
+				fmt.Fprintf(&b, "\n  %s", name)
+			}
+		}
+		t.Error(b.String())
+	}
+
+	for _, name := range common {
+		if opts.compareModes || opts.compareModTimes {
+			diffFileInfo(t, name, got, want, opts)
+		}
+
+		gotData, err := fs.ReadFile(got, name)
+		if err != nil {
+			t.Errorf("failed to read got file %s: %v", name, err)
+			continue
+		}
+
+		wantData, err := fs.ReadFile(want, name)
+		if err != nil {
+			t.Errorf("failed to read want file %s: %v", name, err)
+			continue
+		}
+
+		diffFileContent(t, name, gotData, wantData)
+	}
+}
+
+// diffFileInfo compares the mode and modification time of the file name as
+// requested by opts.
+func diffFileInfo(t expect.TB, name string, got, want fs.FS, opts fsOptions) {
+	t.Helper()
+
+	gotInfo, err := fs.Stat(got, name)
+	if err != nil {
+		t.Errorf("failed to stat got file %s: %v", name, err)
+		return
+	}
+
+	wantInfo, err := fs.Stat(want, name)
+	if err != nil {
+		t.Errorf("failed to stat want file %s: %v", name, err)
+		return
+	}
+
+	if opts.compareModes && gotInfo.Mode() != wantInfo.Mode() {
+		t.Errorf("file %s: expected mode %s but got %s", name, wantInfo.Mode(), gotInfo.Mode())
+	}
+
+	if opts.compareModTimes {
+		gotTime := gotInfo.ModTime().Truncate(time.Second)
+		wantTime := wantInfo.ModTime().Truncate(time.Second)
+		if !gotTime.Equal(wantTime) {
+			t.Errorf("file %s: expected modification time %s but got %s", name, formatTime(wantTime), formatTime(gotTime))
+		}
+	}
+}
+
+// diffFileContent compares the contents of the file name reporting
+// differences either line by line (for text) or as a hex dump (for binary
+// content).
+func diffFileContent(t expect.TB, name string, got, want []byte) {
+	t.Helper()
+
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	if isText(got) && isText(want) {
+		expect.WithMessage(t, "file %s", name).That(EqualToStringByLines(string(got), string(want)))
+		return
+	}
+
+	t.Errorf("file %s: binary content differs%s", name, hexDiff(got, want))
+}
+
+// listFiles returns the sorted names of all regular files in fsys selected
+// by opts.
+func listFiles(fsys fs.FS, opts fsOptions) ([]string, error) {
+	var files []string
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() && opts.selected(name) {
+			files = append(files, name)
+		}
+
+		return nil
+	})
+
+	sort.Strings(files)
+
+	return files, err
+}
+
+func contains(sorted []string, s string) bool {
+	i := sort.SearchStrings(sorted, s)
+	return i < len(sorted) && sorted[i] == s
+}
+
+// isText reports whether data looks like text, i.e. is valid UTF-8 and does
+// not contain any NUL byte.
+func isText(data []byte) bool {
+	return utf8.Valid(data) && bytes.IndexByte(data, 0) < 0
+}
+
+// hexDumpRowLen defines the number of bytes shown per row of a hex dump.
+const hexDumpRowLen = 16
+
+// hexDiff formats the first difference between got and want as a hex dump
+// of the rows surrounding the first differing byte.
+func hexDiff(got, want []byte) string {
+	offset := 0
+	for offset < len(got) && offset < len(want) && got[offset] == want[offset] {
+		offset++
+	}
+
+	start := (offset / hexDumpRowLen) * hexDumpRowLen
+	if start >= hexDumpRowLen {
+		start -= hexDumpRowLen
+	}
+	end := start + 3*hexDumpRowLen
+
+	var b strings.Builder
+	fmt.Fprintf(&b, " at offset %#x (want %d bytes, got %d bytes)", offset, len(want), len(got))
+	b.WriteString("\nwant:")
+	writeHexDump(&b, want, start, end)
+	b.WriteString("\ngot:")
+	writeHexDump(&b, got, start, end)
+
+	return b.String()
+}
+
+func writeHexDump(b *strings.Builder, data []byte, start, end int) {
+	if end > len(data) {
+		end = len(data)
+	}
+
+	if start >= end {
+		b.WriteString("\n  <EOF>")
+		return
+	}
+
+	for row := start; row < end; row += hexDumpRowLen {
+		rowEnd := row + hexDumpRowLen
+		if rowEnd > end {
+			rowEnd = end
+		}
+
+		fmt.Fprintf(b, "\n  %08x ", row)
+		for i := row; i < row+hexDumpRowLen; i++ {
+			if i < rowEnd {
+				fmt.Fprintf(b, " %02x", data[i])
+			} else {
+				b.WriteString("   ")
+			}
+		}
+
+		b.WriteString("  |")
+		for _, c := range data[row:rowEnd] {
+			if c >= 0x20 && c < 0x7f {
+				b.WriteByte(c)
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteString("|")
+	}
+}
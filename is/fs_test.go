@@ -0,0 +1,190 @@
+package is
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestFileExists(t *testing.T) {
+	var tb testhelper.TB
+
+	fsys := fstest.MapFS{
+		"a/b.txt": {Data: []byte("b")},
+	}
+
+	FileExists(fsys, "a/b.txt").Expect(&tb)
+	FileExists(fsys, "a").Expect(&tb)
+	FileExists(fsys, "a/c.txt").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected a to be a regular file but got mode dr-xr-xr-x",
+			"expected file a/c.txt to exist but got error: open a/c.txt: file does not exist",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestDirExists(t *testing.T) {
+	var tb testhelper.TB
+
+	fsys := fstest.MapFS{
+		"a/b.txt": {Data: []byte("b")},
+	}
+
+	DirExists(fsys, "a").Expect(&tb)
+	DirExists(fsys, "a/b.txt").Expect(&tb)
+	DirExists(fsys, "c").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected a/b.txt to be a directory but got mode ----------",
+			"expected directory c to exist but got error: open c: file does not exist",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestFileContent(t *testing.T) {
+	var tb testhelper.TB
+
+	fsys := fstest.MapFS{
+		"a.txt": {Data: []byte("hello, world")},
+	}
+
+	FileContent(fsys, "a.txt", func(content string) expect.Expectation {
+		return StringContaining(content, "world")
+	}).Expect(&tb)
+	FileContent(fsys, "a.txt", func(content string) expect.Expectation {
+		return EqualTo(content, "hello")
+	}).Expect(&tb)
+	FileContent(fsys, "b.txt", func(content string) expect.Expectation {
+		return EqualTo(content, "hello")
+	}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"file a.txt: values are not equal\nwant: hello\ngot:  hello, world",
+			"failed to read file b.txt: open b.txt: file does not exist",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	var tb testhelper.TB
+
+	fsys := fstest.MapFS{
+		"a.sh": {Data: []byte("#!/bin/sh"), Mode: 0o755},
+	}
+
+	FileMode(fsys, "a.sh", 0o755).Expect(&tb)
+	FileMode(fsys, "a.sh", 0o644).Expect(&tb)
+	FileMode(fsys, "b.sh", 0o644).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected a.sh to have mode -rw-r--r-- but got -rwxr-xr-x",
+			"expected b.sh to exist but got error: open b.sh: file does not exist",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestFSEqualTo(t *testing.T) {
+	want := fstest.MapFS{
+		"a.txt":     {Data: []byte("foo\nbar")},
+		"b/c.txt":   {Data: []byte("c")},
+		"b/d.bin":   {Data: []byte{0, 1, 2, 3}},
+		"e.log":     {Data: []byte("log")},
+		"f/g.txt":   {Data: []byte("g")},
+		"f/h/i.txt": {Data: []byte("i")},
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		var tb testhelper.TB
+
+		FSEqualTo(want, want).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+
+	t.Run("differences", func(t *testing.T) {
+		var tb testhelper.TB
+
+		got := fstest.MapFS{
+			"a.txt":     {Data: []byte("foo\nbaz")},
+			"b/d.bin":   {Data: []byte{0, 1, 4, 3, 5}},
+			"e.log":     {Data: []byte("log")},
+			"f/g.txt":   {Data: []byte("g")},
+			"f/h/i.txt": {Data: []byte("i")},
+			"x.txt":     {Data: []byte("x")},
+		}
+
+		FSEqualTo(got, want).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{
+			ErrFlag: true,
+			Logs: []string{
+				"file trees differ:\nmissing files:\n  b/c.txt\nextra files:\n  x.txt",
+				"file a.txt: at line 1: wanted\n\"bar\"\nbut got\n\"baz\"",
+				"file b/d.bin: binary content differs at offset 0x2 (want 4 bytes, got 5 bytes)\nwant:\n  00000000  00 01 02 03                                      |....|\ngot:\n  00000000  00 01 04 03 05                                   |.....|",
+			},
+		}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+
+	t.Run("include and exclude", func(t *testing.T) {
+		var tb testhelper.TB
+
+		got := fstest.MapFS{
+			"a.txt":     {Data: []byte("foo\nbar")},
+			"b/c.txt":   {Data: []byte("c")},
+			"e.log":     {Data: []byte("other")},
+			"f/g.txt":   {Data: []byte("g")},
+			"f/h/i.txt": {Data: []byte("other")},
+		}
+
+		FSEqualTo(got, want, IncludeFiles{"*.txt"}, ExcludeFiles{"f/h/*"}).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+}
+
+func TestHexDiff(t *testing.T) {
+	want := make([]byte, 64)
+	got := make([]byte, 64)
+	copy(want[40:], "want")
+	copy(got[40:], "got!")
+
+	expected := " at offset 0x28 (want 64 bytes, got 64 bytes)" +
+		"\nwant:" +
+		"\n  00000010  00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00  |................|" +
+		"\n  00000020  00 00 00 00 00 00 00 00 77 61 6e 74 00 00 00 00  |........want....|" +
+		"\n  00000030  00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00  |................|" +
+		"\ngot:" +
+		"\n  00000010  00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00  |................|" +
+		"\n  00000020  00 00 00 00 00 00 00 00 67 6f 74 21 00 00 00 00  |........got!....|" +
+		"\n  00000030  00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00  |................|"
+
+	if got := hexDiff(got, want); got != expected {
+		t.Errorf("not expected:\n%s", got)
+	}
+}
@@ -0,0 +1,264 @@
+package is
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/failtrack"
+)
+
+// HTTPResponseSource defines a type constraint for the types HTTPResponse
+// accepts.
+type HTTPResponseSource interface {
+	*http.Response | *httptest.ResponseRecorder
+}
+
+// HTTPResponseExpectation defines an expectation on an HTTP response. body
+// contains the response's complete body which has already been read from
+// resp.Body.
+type HTTPResponseExpectation func(t expect.TB, resp *http.Response, body []byte)
+
+// httpDumpLimit defines the maximum number of bytes of a response dump
+// logged when an HTTPResponse expectation fails.
+const httpDumpLimit = 2048
+
+// HTTPResponse runs expectations on the response resp which may either be a *http.Response or a
+// *httptest.ResponseRecorder. The response's body is read completely and replaced so it can be read again
+// afterwards. If any of the expectations fails, a dump of the response (truncated to a reasonable length) is
+// logged before the first failure.
+func HTTPResponse[R HTTPResponseSource](resp R, expectations ...HTTPResponseExpectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var r *http.Response
+		switch x := any(resp).(type) {
+		case *http.Response:
+			r = x
+		case *httptest.ResponseRecorder:
+			r = x.Result()
+		}
+
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				t.Errorf("failed to read response body: %v", err)
+				return
+			}
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		tracker := failtrack.Wrap(t, func() {
+			t.Helper()
+			t.Logf("response:\n%s", dumpResponse(r, body))
+		})
+		for _, e := range expectations {
+			e(tracker, r, body)
+		}
+	}), resp)
+}
+
+// dumpResponse formats resp in the style of httputil.DumpResponse
+// truncating the result to httpDumpLimit bytes.
+func dumpResponse(resp *http.Response, body []byte) string {
+	r := *resp
+	r.ContentLength = int64(len(body))
+	r.TransferEncoding = nil
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	dump, err := httputil.DumpResponse(&r, true)
+	if err != nil {
+		return fmt.Sprintf("<failed to dump response: %v>", err)
+	}
+
+	s := strings.ReplaceAll(string(dump), "\r\n", "\n")
+	if len(s) > httpDumpLimit {
+		s = fmt.Sprintf("%s\n... (truncated, %d bytes total)", s[:httpDumpLimit], len(s))
+	}
+
+	return s
+}
+
+func formatStatus(resp *http.Response) string {
+	if resp.Status != "" {
+		return resp.Status
+	}
+	return fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+}
+
+// Status expects the response's status code to equal want.
+func Status(want int) HTTPResponseExpectation {
+	return func(t expect.TB, resp *http.Response, _ []byte) {
+		t.Helper()
+
+		if resp.StatusCode != want {
+			expect.ReportValues(t, resp.StatusCode, want)
+			t.Errorf("expected status %d %s but got %s", want, http.StatusText(want), formatStatus(resp))
+		}
+	}
+}
+
+// StatusClass expects the response's status code to belong to the given
+// class, i.e. StatusClass(2) expects any 2xx status.
+func StatusClass(class int) HTTPResponseExpectation {
+	return func(t expect.TB, resp *http.Response, _ []byte) {
+		t.Helper()
+
+		if resp.StatusCode/100 != class {
+			t.Errorf("expected status %dxx but got %s", class, formatStatus(resp))
+		}
+	}
+}
+
+// HeaderPresent expects the response to contain the header name.
+func HeaderPresent(name string) HTTPResponseExpectation {
+	return func(t expect.TB, resp *http.Response, _ []byte) {
+		t.Helper()
+
+		name = http.CanonicalHeaderKey(name)
+		if _, ok := resp.Header[name]; !ok {
+			t.Errorf("expected header %s to be present", name)
+		}
+	}
+}
+
+// HeaderValue expects the first value of the response's header name to
+// equal want.
+func HeaderValue(name, want string) HTTPResponseExpectation {
+	return func(t expect.TB, resp *http.Response, _ []byte) {
+		t.Helper()
+
+		name = http.CanonicalHeaderKey(name)
+		values, ok := resp.Header[name]
+		if !ok {
+			t.Errorf("expected header %s to be %q but it is not present", name, want)
+			return
+		}
+
+		if values[0] != want {
+			expect.ReportValues(t, values[0], want)
+			t.Errorf("expected header %s to be %q but got %q", name, want, values[0])
+		}
+	}
+}
+
+// HeaderValues expects the response's header name to contain exactly the
+// values want in the given order.
+func HeaderValues(name string, want ...string) HTTPResponseExpectation {
+	return func(t expect.TB, resp *http.Response, _ []byte) {
+		t.Helper()
+
+		name = http.CanonicalHeaderKey(name)
+		got := resp.Header.Values(name)
+
+		if len(got) != len(want) {
+			t.Errorf("expected header %s to have values %q but got %q", name, want, got)
+			return
+		}
+
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("expected header %s to have values %q but got %q", name, want, got)
+				return
+			}
+		}
+	}
+}
+
+// ContentType expects the response's Content-Type header to specify the
+// media type mediaType (compared case-insensitively) and to contain all of
+// the given params. The charset parameter is compared case-insensitively,
+// all other parameters are compared exactly.
+func ContentType(mediaType string, params map[string]string) HTTPResponseExpectation {
+	return func(t expect.TB, resp *http.Response, _ []byte) {
+		t.Helper()
+
+		header := resp.Header.Get("Content-Type")
+		if header == "" {
+			t.Errorf("expected content type %s but no Content-Type header is present", mediaType)
+			return
+		}
+
+		got, gotParams, err := mime.ParseMediaType(header)
+		if err != nil {
+			t.Errorf("expected content type %s but got invalid Content-Type header %q: %v", mediaType, header, err)
+			return
+		}
+
+		if !strings.EqualFold(got, mediaType) {
+			t.Errorf("expected content type %s but got %q", mediaType, header)
+			return
+		}
+
+		for _, name := range sortedMapKeys(params) {
+			want := params[name]
+			value, ok := gotParams[strings.ToLower(name)]
+			if !ok {
+				t.Errorf("expected content type parameter %s=%q but it is not present in %q", name, want, header)
+				continue
+			}
+
+			if value != want && !(strings.EqualFold(name, "charset") && strings.EqualFold(value, want)) {
+				t.Errorf("expected content type parameter %s=%q but got %q", name, want, value)
+			}
+		}
+	}
+}
+
+// Cookie expects the response to set the cookie name. If f is not nil, the
+// expectation created by calling f with the cookie is run as well. Failures
+// are prefixed with the cookie's name.
+func Cookie(name string, f func(c *http.Cookie) expect.Expectation) HTTPResponseExpectation {
+	return func(t expect.TB, resp *http.Response, _ []byte) {
+		t.Helper()
+
+		for _, c := range resp.Cookies() {
+			if c.Name != name {
+				continue
+			}
+
+			if f != nil {
+				expect.WithMessage(t, "cookie %s", name).That(f(c))
+			}
+			return
+		}
+
+		t.Errorf("expected cookie %s to be set", name)
+	}
+}
+
+// Body runs the expectation created by calling f with the response's body.
+// Failures are prefixed with "body".
+func Body(f func(body string) expect.Expectation) HTTPResponseExpectation {
+	return func(t expect.TB, _ *http.Response, body []byte) {
+		t.Helper()
+		expect.WithMessage(t, "body").That(f(string(body)))
+	}
+}
+
+// BodyJSON expects the response's body to be a JSON document equal to want.
+// See JSONEqualTo for details.
+func BodyJSON(want string, opts ...JSONOpt) HTTPResponseExpectation {
+	return func(t expect.TB, _ *http.Response, body []byte) {
+		t.Helper()
+		JSONEqualTo(body, want, opts...).Expect(t)
+	}
+}
+
+// BodyJSONAt selects nodes from the response's JSON body and runs the
+// expectations created by f on them. See JSONAt for details.
+func BodyJSONAt(path string, f func(node any) expect.Expectation) HTTPResponseExpectation {
+	return func(t expect.TB, _ *http.Response, body []byte) {
+		t.Helper()
+		JSONAt(body, path, f).Expect(t)
+	}
+}
@@ -0,0 +1,148 @@
+package is
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func recordResponse(t *testing.T) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	rec.Header().Add("Vary", "Accept")
+	rec.Header().Add("Vary", "Accept-Encoding")
+	http.SetCookie(rec, &http.Cookie{Name: "session", Value: "abc", HttpOnly: true})
+	rec.WriteHeader(http.StatusCreated)
+	io.WriteString(rec, `{"id":1,"name":"Alice"}`)
+
+	return rec
+}
+
+func TestHTTPResponse(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var tb testhelper.TB
+
+		HTTPResponse(recordResponse(t),
+			Status(http.StatusCreated),
+			StatusClass(2),
+			HeaderPresent("vary"),
+			HeaderValue("Vary", "Accept"),
+			HeaderValues("Vary", "Accept", "Accept-Encoding"),
+			ContentType("application/json", map[string]string{"charset": "utf-8"}),
+			Cookie("session", nil),
+			Cookie("session", func(c *http.Cookie) expect.Expectation {
+				return EqualTo(c.Value, "abc")
+			}),
+			Body(func(body string) expect.Expectation {
+				return StringContaining(body, "Alice")
+			}),
+			BodyJSON(`{"name": "Alice", "id": 1}`),
+			BodyJSONAt("$.name", JSONNodeEqualTo(`"Alice"`)),
+		).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		var tb testhelper.TB
+
+		HTTPResponse(recordResponse(t),
+			Status(http.StatusOK),
+			StatusClass(4),
+			HeaderPresent("X-Request-Id"),
+			HeaderValue("Vary", "Origin"),
+			HeaderValue("X-Request-Id", "1"),
+			HeaderValues("Vary", "Accept"),
+			ContentType("text/plain", nil),
+			ContentType("application/json", map[string]string{"charset": "latin1", "boundary": "x"}),
+			Cookie("token", nil),
+			Cookie("session", func(c *http.Cookie) expect.Expectation {
+				return EqualTo(c.Secure, true)
+			}),
+			Body(func(body string) expect.Expectation {
+				return StringContaining(body, "Bob")
+			}),
+		).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{
+			ErrFlag: true,
+			Logs: []string{
+				"response:\nHTTP/1.1 201 Created\nContent-Length: 23\nContent-Type: application/json; charset=UTF-8\nSet-Cookie: session=abc; HttpOnly\nVary: Accept\nVary: Accept-Encoding\n\n{\"id\":1,\"name\":\"Alice\"}",
+				"expected status 200 OK but got 201 Created",
+				"expected status 4xx but got 201 Created",
+				"expected header X-Request-Id to be present",
+				"expected header Vary to be \"Origin\" but got \"Accept\"",
+				"expected header X-Request-Id to be \"1\" but it is not present",
+				"expected header Vary to have values [\"Accept\"] but got [\"Accept\" \"Accept-Encoding\"]",
+				"expected content type text/plain but got \"application/json; charset=UTF-8\"",
+				"expected content type parameter boundary=\"x\" but it is not present in \"application/json; charset=UTF-8\"",
+				"expected content type parameter charset=\"latin1\" but got \"UTF-8\"",
+				"expected cookie token to be set",
+				"cookie session: values are not equal\nwant: true\ngot:  false",
+				"body: expected \"{\\\"id\\\":1,\\\"name\\\":\\\"Alice\\\"}\" to contain \"Bob\"",
+			},
+		}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+
+	t.Run("fatal", func(t *testing.T) {
+		var tb testhelper.TB
+
+		expect.FailNow(HTTPResponse(recordResponse(t), Status(http.StatusOK))).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{
+			FatalFlag: true,
+			Logs: []string{
+				"response:\nHTTP/1.1 201 Created\nContent-Length: 23\nContent-Type: application/json; charset=UTF-8\nSet-Cookie: session=abc; HttpOnly\nVary: Accept\nVary: Accept-Encoding\n\n{\"id\":1,\"name\":\"Alice\"}",
+				"expected status 200 OK but got 201 Created",
+			},
+		}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+
+	t.Run("body can be read again", func(t *testing.T) {
+		var tb testhelper.TB
+
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("hello")),
+		}
+
+		HTTPResponse(resp, Status(http.StatusOK)).Expect(&tb)
+
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "hello" || tb.ErrFlag {
+			t.Errorf("not expected: %q %#v", body, tb)
+		}
+	})
+}
+
+func TestDumpResponse(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+	}
+
+	dump := dumpResponse(resp, []byte(strings.Repeat("x", 2*httpDumpLimit)))
+
+	if !strings.HasPrefix(dump, "HTTP/1.1 200 OK\nContent-Length: 4096\n\nxxx") ||
+		!strings.HasSuffix(dump, "x\n... (truncated, 4134 bytes total)") ||
+		len(dump) != httpDumpLimit+len("\n... (truncated, 4134 bytes total)") {
+		t.Errorf("not expected: %q", dump)
+	}
+}
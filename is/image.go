@@ -0,0 +1,151 @@
+package is
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/halimath/expect"
+)
+
+// ImageOpt defines an interface for types that can be used as options for
+// ImageEqualTo.
+type ImageOpt interface {
+	imageOpt()
+}
+
+// ChannelTolerance is an ImageOpt that defines the maximum difference of
+// any color or alpha channel (on a scale from 0 to 255) for two pixels to
+// still be considered equal.
+type ChannelTolerance uint8
+
+func (ChannelTolerance) imageOpt() {}
+
+// MaxDifferingPixels is an ImageOpt that defines the maximum share (between
+// 0 and 1) of pixels that may differ for two images to still be considered
+// equal.
+type MaxDifferingPixels float64
+
+func (MaxDifferingPixels) imageOpt() {}
+
+// ImageEqualTo expects got and want to have the same size and equal pixels. Pixels are compared channel by
+// channel after converting them to 8-bit RGBA; use opts to tolerate small differences. Bounds are compared by
+// size only, so a sub image can be compared to an image starting at the origin.
+//
+// On failure the images got and want as well as a diff image highlighting the differing pixels in red are
+// written as PNG files to t.TempDir() and their paths are reported together with the mismatch statistics.
+func ImageEqualTo(got, want image.Image, opts ...ImageOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var tolerance uint8
+		var maxShare float64
+
+		for _, opt := range opts {
+			switch x := opt.(type) {
+			case ChannelTolerance:
+				tolerance = uint8(x)
+			case MaxDifferingPixels:
+				maxShare = float64(x)
+			}
+		}
+
+		gotBounds, wantBounds := got.Bounds(), want.Bounds()
+		if gotBounds.Size() != wantBounds.Size() {
+			t.Errorf("expected image of size %dx%d but got %dx%d", wantBounds.Dx(), wantBounds.Dy(), gotBounds.Dx(), gotBounds.Dy())
+			return
+		}
+
+		diffImage := image.NewNRGBA(image.Rect(0, 0, wantBounds.Dx(), wantBounds.Dy()))
+		differing := 0
+		var maxDiff uint8
+
+		for y := 0; y < wantBounds.Dy(); y++ {
+			for x := 0; x < wantBounds.Dx(); x++ {
+				g := color.NRGBAModel.Convert(got.At(gotBounds.Min.X+x, gotBounds.Min.Y+y)).(color.NRGBA)
+				w := color.NRGBAModel.Convert(want.At(wantBounds.Min.X+x, wantBounds.Min.Y+y)).(color.NRGBA)
+
+				d := channelDiff(g, w)
+				if d > maxDiff {
+					maxDiff = d
+				}
+
+				if d > tolerance {
+					differing++
+					diffImage.SetNRGBA(x, y, color.NRGBA{R: 0xff, A: 0xff})
+				} else {
+					diffImage.SetNRGBA(x, y, fadedPixel(w))
+				}
+			}
+		}
+
+		total := wantBounds.Dx() * wantBounds.Dy()
+		if differing == 0 || float64(differing) <= maxShare*float64(total) {
+			return
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "images differ: %d of %d pixels (%.2f%%) differ by more than %d (maximum allowed: %.2f%%); maximum channel difference: %d",
+			differing, total, 100*float64(differing)/float64(total), tolerance, 100*maxShare, maxDiff)
+
+		dir := t.TempDir()
+		for _, f := range []struct {
+			label string
+			img   image.Image
+		}{
+			{"got", got},
+			{"want", want},
+			{"diff", diffImage},
+		} {
+			name := filepath.Join(dir, f.label+".png")
+			if err := writePNG(name, f.img); err != nil {
+				fmt.Fprintf(&b, "\n%s: failed to write image: %v", f.label, err)
+				continue
+			}
+			fmt.Fprintf(&b, "\n%s: %s", f.label, name)
+		}
+
+		t.Error(b.String())
+	}), got, want)
+}
+
+// channelDiff returns the maximum difference of any channel of a and b.
+func channelDiff(a, b color.NRGBA) uint8 {
+	var d uint8
+	for _, c := range [][2]uint8{{a.R, b.R}, {a.G, b.G}, {a.B, b.B}, {a.A, b.A}} {
+		x := c[0] - c[1]
+		if c[1] > c[0] {
+			x = c[1] - c[0]
+		}
+		if x > d {
+			d = x
+		}
+	}
+	return d
+}
+
+// fadedPixel converts c to a light gray used as the background of diff
+// images so differing pixels stand out.
+func fadedPixel(c color.NRGBA) color.NRGBA {
+	y := color.GrayModel.Convert(c).(color.Gray).Y
+	v := 0xc0 + y/4
+	return color.NRGBA{R: v, G: v, B: v, A: 0xff}
+}
+
+func writePNG(name string, img image.Image) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,112 @@
+package is
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+type tempDirTB struct {
+	testhelper.TB
+	dir string
+}
+
+func (t *tempDirTB) TempDir() string { return t.dir }
+
+func filledImage(r image.Rectangle, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestImageEqualTo(t *testing.T) {
+	blue := color.NRGBA{B: 200, A: 255}
+	want := filledImage(image.Rect(0, 0, 10, 10), blue)
+
+	t.Run("equal", func(t *testing.T) {
+		tb := tempDirTB{dir: t.TempDir()}
+
+		got := filledImage(image.Rect(0, 0, 20, 20), color.White).SubImage(image.Rect(5, 5, 15, 15)).(*image.NRGBA)
+		for y := 5; y < 15; y++ {
+			for x := 5; x < 15; x++ {
+				got.Set(x, y, blue)
+			}
+		}
+
+		ImageEqualTo(got, want).Expect(&tb)
+
+		slightlyDifferent := filledImage(image.Rect(0, 0, 10, 10), color.NRGBA{B: 203, A: 255})
+		slightlyDifferent.Set(0, 0, color.Black)
+		ImageEqualTo(slightlyDifferent, want, ChannelTolerance(3), MaxDifferingPixels(0.01)).Expect(&tb)
+
+		if !reflect.DeepEqual(tb.TB, testhelper.TB{}) {
+			t.Errorf("not expected: %#v", tb.TB)
+		}
+	})
+
+	t.Run("different size", func(t *testing.T) {
+		tb := tempDirTB{dir: t.TempDir()}
+
+		ImageEqualTo(filledImage(image.Rect(0, 0, 10, 8), blue), want).Expect(&tb)
+
+		if !reflect.DeepEqual(tb.TB, testhelper.TB{
+			ErrFlag: true,
+			Logs:    []string{"expected image of size 10x10 but got 10x8"},
+		}) {
+			t.Errorf("not expected: %#v", tb.TB)
+		}
+	})
+
+	t.Run("different pixels", func(t *testing.T) {
+		tb := tempDirTB{dir: t.TempDir()}
+
+		got := filledImage(image.Rect(0, 0, 10, 10), color.NRGBA{B: 202, A: 255})
+		got.Set(1, 2, color.NRGBA{R: 10, B: 200, A: 255})
+		got.Set(3, 4, color.NRGBA{R: 20, B: 200, A: 255})
+
+		ImageEqualTo(got, want, ChannelTolerance(2), MaxDifferingPixels(0.01)).Expect(&tb)
+
+		if len(tb.Logs) != 1 {
+			t.Fatalf("not expected: %#v", tb.TB)
+		}
+
+		log := strings.ReplaceAll(tb.Logs[0], tb.dir, "TMP")
+		want := "images differ: 2 of 100 pixels (2.00%) differ by more than 2 (maximum allowed: 1.00%); maximum channel difference: 20" +
+			"\ngot: " + filepath.Join("TMP", "got.png") +
+			"\nwant: " + filepath.Join("TMP", "want.png") +
+			"\ndiff: " + filepath.Join("TMP", "diff.png")
+		if !tb.ErrFlag || log != want {
+			t.Errorf("not expected: %#v", tb.TB)
+		}
+
+		f, err := os.Open(filepath.Join(tb.dir, "diff.png"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		diff, err := png.Decode(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c := color.NRGBAModel.Convert(diff.At(1, 2)); c != (color.NRGBA{R: 0xff, A: 0xff}) {
+			t.Errorf("expected differing pixel to be highlighted but got %v", c)
+		}
+
+		if c := color.NRGBAModel.Convert(diff.At(0, 0)).(color.NRGBA); c.R != c.G || c.G != c.B {
+			t.Errorf("expected equal pixel to be gray but got %v", c)
+		}
+	})
+}
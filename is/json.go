@@ -0,0 +1,331 @@
+package is
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/halimath/expect"
+)
+
+// JSONDocument is a constraint for types holding a JSON document in its
+// textual representation, such as string, []byte or json.RawMessage.
+type JSONDocument interface {
+	~string | ~[]byte
+}
+
+// JSONOpt defines an interface for types that can be used as options for
+// JSONEqualTo.
+type JSONOpt interface {
+	jsonOpt()
+}
+
+// IgnoreArrayOrder is a JSONOpt that defines whether the order of elements in
+// JSON arrays is significant or not. If set to true, arrays are considered
+// equal if they contain the same elements (including duplicates) in any
+// order. Elements of want that have no equal counterpart are compared to
+// the most similar remaining element of got and differences are reported
+// using that element's index in got.
+type IgnoreArrayOrder bool
+
+func (IgnoreArrayOrder) jsonOpt() {}
+
+// NumbersNumerically is a JSONOpt that defines whether JSON numbers are
+// compared by their numeric value (so that 1, 1.0 and 1e0 are equal) or by
+// their textual representation (the default).
+type NumbersNumerically bool
+
+func (NumbersNumerically) jsonOpt() {}
+
+// IgnoreExtraKeys is a JSONOpt that defines whether object keys contained in
+// got but not in want are ignored or reported as differences.
+type IgnoreExtraKeys bool
+
+func (IgnoreExtraKeys) jsonOpt() {}
+
+// JSONEqualTo expects got and want to contain semantically equal JSON documents. Both documents are decoded
+// (using json.Number for numbers) and compared structurally, reporting differences like DeepEqualTo. Thus, neither
+// whitespace nor the order of object keys is significant. Differences are reported using JSON Pointer
+// (RFC 6901) paths such as /items/3/name. Use opts to customize the comparison.
+func JSONEqualTo[G, W JSONDocument](got G, want W, opts ...JSONOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		gotVal, err := decodeJSON([]byte(got))
+		if err != nil {
+			t.Errorf("failed to decode got as JSON: %v", err)
+			return
+		}
+
+		wantVal, err := decodeJSON([]byte(want))
+		if err != nil {
+			t.Errorf("failed to decode want as JSON: %v", err)
+			return
+		}
+
+		if d := jsonDiff(wantVal, gotVal, opts); len(d) > 0 {
+			t.Errorf("JSON documents are not equal:%s", d)
+		}
+	}), got, want)
+}
+
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data following JSON value at offset %d", dec.InputOffset())
+	}
+
+	return v, nil
+}
+
+// jsonDiff compares the decoded JSON values want and got.
+func jsonDiff(want, got any, opts []JSONOpt) diff {
+	var c jsonComparison
+	var numbersNumerically bool
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case IgnoreArrayOrder:
+			c.ignoreArrayOrder = bool(o)
+		case NumbersNumerically:
+			numbersNumerically = bool(o)
+		case IgnoreExtraKeys:
+			c.ignoreExtraKeys = bool(o)
+		}
+	}
+
+	if numbersNumerically {
+		want = normalizeJSONNumbers(want)
+		got = normalizeJSONNumbers(got)
+	}
+
+	var d diff
+	c.compare(&d, "", want, got)
+	return d
+}
+
+// jsonComparison compares decoded JSON values producing diff entries with
+// JSON Pointer paths.
+type jsonComparison struct {
+	ignoreArrayOrder bool
+	ignoreExtraKeys  bool
+}
+
+func (c *jsonComparison) compare(d *diff, path string, want, got any) {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			break
+		}
+
+		for _, k := range sortedMapKeys(w) {
+			p := path + "/" + escapeJSONPointerToken(k)
+			if gv, ok := g[k]; ok {
+				c.compare(d, p, w[k], gv)
+			} else {
+				*d = append(*d, diffEntry{path: p, want: formatJSON(w[k]), got: "<missing map key>"})
+			}
+		}
+
+		if !c.ignoreExtraKeys {
+			for _, k := range sortedMapKeys(g) {
+				if _, ok := w[k]; !ok {
+					*d = append(*d, diffEntry{path: path + "/" + escapeJSONPointerToken(k), want: "<missing map key>", got: formatJSON(g[k])})
+				}
+			}
+		}
+		return
+
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			break
+		}
+
+		if c.ignoreArrayOrder {
+			c.compareUnordered(d, path, w, g)
+			return
+		}
+
+		for i := range w {
+			p := fmt.Sprintf("%s/%d", path, i)
+			if i < len(g) {
+				c.compare(d, p, w[i], g[i])
+			} else {
+				*d = append(*d, diffEntry{path: p, want: formatJSON(w[i]), got: "<missing slice index>"})
+			}
+		}
+		for i := len(w); i < len(g); i++ {
+			*d = append(*d, diffEntry{path: fmt.Sprintf("%s/%d", path, i), want: "<unwanted slice index>", got: formatJSON(g[i])})
+		}
+		return
+
+	default:
+		if reflect.TypeOf(want) == reflect.TypeOf(got) && want == got {
+			return
+		}
+	}
+
+	*d = append(*d, diffEntry{path: path, want: formatJSON(want), got: formatJSON(got)})
+}
+
+// compareUnordered compares the arrays want and got as multisets. Elements
+// are matched using a maximum bipartite matching so that each element of
+// want is matched with an equal element of got whenever possible. Each
+// unmatched element of want is then compared to the closest unmatched
+// element of got reporting differences at that element's index. Remaining
+// elements are reported as missing or unwanted.
+func (c *jsonComparison) compareUnordered(d *diff, path string, want, got []any) {
+	equal := make([][]bool, len(want))
+	for i := range want {
+		equal[i] = make([]bool, len(got))
+		for j := range got {
+			var ed diff
+			c.compare(&ed, "", want[i], got[j])
+			equal[i][j] = len(ed) == 0
+		}
+	}
+
+	// matchOf maps indexes of got to the index of the matched element of
+	// want or -1.
+	matchOf := make([]int, len(got))
+	for j := range matchOf {
+		matchOf[j] = -1
+	}
+
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j := range got {
+			if !equal[i][j] || seen[j] {
+				continue
+			}
+			seen[j] = true
+			if matchOf[j] < 0 || augment(matchOf[j], seen) {
+				matchOf[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	matched := make([]bool, len(want))
+	for i := range want {
+		matched[i] = augment(i, make([]bool, len(got)))
+	}
+
+	used := make([]bool, len(got))
+	for j, i := range matchOf {
+		used[j] = i >= 0
+	}
+
+	for i := range want {
+		if matched[i] {
+			continue
+		}
+
+		closest := -1
+		var closestDiff diff
+		for j := range got {
+			if used[j] {
+				continue
+			}
+
+			var ed diff
+			c.compare(&ed, fmt.Sprintf("%s/%d", path, j), want[i], got[j])
+			if closest < 0 || len(ed) < len(closestDiff) {
+				closest, closestDiff = j, ed
+			}
+		}
+
+		if closest < 0 {
+			*d = append(*d, diffEntry{path: path, want: formatJSON(want[i]), got: "<missing array element>"})
+			continue
+		}
+
+		used[closest] = true
+		*d = append(*d, closestDiff...)
+	}
+
+	for j := range got {
+		if !used[j] {
+			*d = append(*d, diffEntry{path: fmt.Sprintf("%s/%d", path, j), want: "<unwanted array element>", got: formatJSON(got[j])})
+		}
+	}
+}
+
+// formatJSON formats the decoded JSON value v using its JSON representation.
+func formatJSON(v any) string {
+	return formatJSONValue(reflect.ValueOf(v))
+}
+
+// formatJSONValue formats v using its JSON representation.
+func formatJSONValue(v reflect.Value) string {
+	if !v.IsValid() || (v.Kind() == reflect.Interface && v.IsNil()) {
+		return "null"
+	}
+
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
+
+// normalizeJSONNumbers returns a copy of v with every json.Number replaced
+// by a canonical representation of its numeric value.
+func normalizeJSONNumbers(v any) any {
+	switch x := v.(type) {
+	case json.Number:
+		f, _, err := big.ParseFloat(string(x), 10, 256, big.ToNearestEven)
+		if err != nil {
+			return x
+		}
+		return json.Number(f.Text('g', -1))
+
+	case []any:
+		res := make([]any, len(x))
+		for i := range x {
+			res[i] = normalizeJSONNumbers(x[i])
+		}
+		return res
+
+	case map[string]any:
+		res := make(map[string]any, len(x))
+		for k := range x {
+			res[k] = normalizeJSONNumbers(x[k])
+		}
+		return res
+
+	default:
+		return v
+	}
+}
+
+// escapeJSONPointerToken escapes s to be used as a reference token in a JSON
+// Pointer as defined in RFC 6901.
+func escapeJSONPointerToken(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	return strings.ReplaceAll(s, "/", "~1")
+}
+
+// displayJSONPointer formats the JSON Pointer p for display. As the empty
+// pointer referring to the whole document is easily overlooked and "/"
+// refers to the member with an empty name, it is displayed as (root).
+func displayJSONPointer(p string) string {
+	if p == "" {
+		return "(root)"
+	}
+	return p
+}
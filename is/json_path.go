@@ -0,0 +1,425 @@
+package is
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/halimath/expect"
+)
+
+// JSONAt selects nodes from the JSON document got and runs the expectations created by calling f for every
+// selected node. The node is passed to f in its decoded form, i.e. as map[string]any, []any, string,
+// json.Number, bool or nil. Failures of these expectations are prefixed with the node's JSON Pointer path.
+//
+// path is either a JSON Pointer as defined by RFC 6901 (such as /data/0/id) or a JSONPath expression
+// starting with $. The following subset of JSONPath is supported:
+//
+//	$.name, $['name']      child member
+//	$[0]                   array element
+//	$.*, $[*]              all members of an object or elements of an array
+//	$[?(@.name == 'foo')]  elements having a member (path) equal to a JSON literal
+//
+// If path selects no node, the failure reports the deepest path that did exist.
+func JSONAt[D JSONDocument](got D, path string, f func(node any) expect.Expectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		doc, err := decodeJSON([]byte(got))
+		if err != nil {
+			t.Errorf("failed to decode got as JSON: %v", err)
+			return
+		}
+
+		nodes, err := selectJSONNodes(doc, path)
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+
+		for _, n := range nodes {
+			expect.WithMessage(t, "at %s", n.displayPath()).That(f(n.value))
+		}
+	}), got, path, f)
+}
+
+// JSONNodeEqualTo creates a function to be used with JSONAt that expects the selected node to be semantically
+// equal to the JSON document want (see JSONEqualTo).
+func JSONNodeEqualTo(want string, opts ...JSONOpt) func(node any) expect.Expectation {
+	return func(node any) expect.Expectation {
+		return expect.ExpectFunc(func(t expect.TB) {
+			t.Helper()
+
+			wantVal, err := decodeJSON([]byte(want))
+			if err != nil {
+				t.Errorf("failed to decode want as JSON: %v", err)
+				return
+			}
+
+			if d := jsonDiff(wantVal, node, opts); len(d) > 0 {
+				t.Errorf("JSON values are not equal:%s", d)
+			}
+		})
+	}
+}
+
+// jsonNode is a node selected from a JSON document along with its location
+// given as a JSON Pointer.
+type jsonNode struct {
+	path  string
+	value any
+}
+
+func (n jsonNode) displayPath() string {
+	return displayJSONPointer(n.path)
+}
+
+func (n jsonNode) child(token string, value any) jsonNode {
+	return jsonNode{
+		path:  n.path + "/" + escapeJSONPointerToken(token),
+		value: value,
+	}
+}
+
+// children returns all members of an object (in sorted key order) or all
+// elements of an array.
+func (n jsonNode) children() []jsonNode {
+	switch v := n.value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		res := make([]jsonNode, len(keys))
+		for i, k := range keys {
+			res[i] = n.child(k, v[k])
+		}
+		return res
+
+	case []any:
+		res := make([]jsonNode, len(v))
+		for i := range v {
+			res[i] = n.child(strconv.Itoa(i), v[i])
+		}
+		return res
+
+	default:
+		return nil
+	}
+}
+
+// member returns the member named token of an object or the element with
+// index token of an array.
+func (n jsonNode) member(token string) (jsonNode, bool) {
+	switch v := n.value.(type) {
+	case map[string]any:
+		val, ok := v[token]
+		if !ok {
+			return jsonNode{}, false
+		}
+		return n.child(token, val), true
+
+	case []any:
+		idx, err := strconv.Atoi(token)
+		if err != nil || idx < 0 || idx >= len(v) || (len(token) > 1 && token[0] == '0') {
+			return jsonNode{}, false
+		}
+		return n.child(token, v[idx]), true
+
+	default:
+		return jsonNode{}, false
+	}
+}
+
+func describeJSONNode(v any) string {
+	switch x := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return fmt.Sprintf("an object with keys %q", keys)
+	case []any:
+		return fmt.Sprintf("an array of len %d", len(x))
+	case nil:
+		return "null"
+	default:
+		b, _ := json.Marshal(x)
+		return string(b)
+	}
+}
+
+func selectJSONNodes(doc any, path string) ([]jsonNode, error) {
+	if strings.HasPrefix(path, "$") {
+		return selectJSONPath(doc, path)
+	}
+	return selectJSONPointer(doc, path)
+}
+
+func selectJSONPointer(doc any, pointer string) ([]jsonNode, error) {
+	n := jsonNode{value: doc}
+
+	if pointer == "" {
+		return []jsonNode{n}, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with /", pointer)
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		c, ok := n.member(token)
+		if !ok {
+			return nil, fmt.Errorf("path %s does not exist; deepest existing path is %s which is %s", pointer, n.displayPath(), describeJSONNode(n.value))
+		}
+		n = c
+	}
+
+	return []jsonNode{n}, nil
+}
+
+// jsonPathSegment is a single step of a JSONPath expression mapping a node to
+// any number of child nodes.
+type jsonPathSegment struct {
+	expr     string
+	selectFn func(jsonNode) []jsonNode
+}
+
+func selectJSONPath(doc any, path string) ([]jsonNode, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON path %q: %v", path, err)
+	}
+
+	nodes := []jsonNode{{value: doc}}
+	matched := "$"
+
+	for _, seg := range segments {
+		var next []jsonNode
+		for _, n := range nodes {
+			next = append(next, seg.selectFn(n)...)
+		}
+
+		if len(next) == 0 {
+			deepest := make([]string, len(nodes))
+			for i, n := range nodes {
+				deepest[i] = fmt.Sprintf("%s which is %s", n.displayPath(), describeJSONNode(n.value))
+			}
+			return nil, fmt.Errorf("path %s does not match any node; deepest matching path is %s selecting %s", path, matched, strings.Join(deepest, ", "))
+		}
+
+		nodes = next
+		matched += seg.expr
+	}
+
+	return nodes, nil
+}
+
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	p := path[1:]
+	var segments []jsonPathSegment
+
+	for len(p) > 0 {
+		switch {
+		case strings.HasPrefix(p, ".."):
+			return nil, errors.New("recursive descent is not supported")
+
+		case p[0] == '.':
+			end := strings.IndexAny(p[1:], ".[")
+			if end < 0 {
+				end = len(p) - 1
+			}
+			name := p[1 : end+1]
+			if name == "" {
+				return nil, errors.New("empty member name")
+			}
+			segments = append(segments, jsonPathMember(p[:end+1], name))
+			p = p[end+1:]
+
+		case p[0] == '[':
+			end, err := findClosingBracket(p)
+			if err != nil {
+				return nil, err
+			}
+
+			seg, err := parseJSONPathBracket(p[:end+1])
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+			p = p[end+1:]
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q", p[0])
+		}
+	}
+
+	return segments, nil
+}
+
+// findClosingBracket returns the index of the bracket closing the one at
+// the start of p. Brackets inside quoted strings are ignored.
+func findClosingBracket(p string) (int, error) {
+	var quote byte
+	depth := 0
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, errors.New("missing ]")
+}
+
+func parseJSONPathBracket(expr string) (jsonPathSegment, error) {
+	inner := strings.TrimSpace(expr[1 : len(expr)-1])
+
+	switch {
+	case inner == "*":
+		return jsonPathMember(expr, "*"), nil
+
+	case strings.HasPrefix(inner, "?(") && strings.HasSuffix(inner, ")"):
+		return parseJSONPathFilter(expr, strings.TrimSpace(inner[2:len(inner)-1]))
+
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		name, err := unquoteJSONPathString(inner)
+		if err != nil {
+			return jsonPathSegment{}, err
+		}
+		return jsonPathSegment{
+			expr:     expr,
+			selectFn: selectMember(name),
+		}, nil
+
+	default:
+		if _, err := strconv.Atoi(inner); err != nil {
+			return jsonPathSegment{}, fmt.Errorf("invalid array index %q", inner)
+		}
+		return jsonPathSegment{
+			expr:     expr,
+			selectFn: selectMember(inner),
+		}, nil
+	}
+}
+
+func jsonPathMember(expr, name string) jsonPathSegment {
+	if name == "*" {
+		return jsonPathSegment{
+			expr:     expr,
+			selectFn: func(n jsonNode) []jsonNode { return n.children() },
+		}
+	}
+
+	return jsonPathSegment{
+		expr:     expr,
+		selectFn: selectMember(name),
+	}
+}
+
+func selectMember(name string) func(jsonNode) []jsonNode {
+	return func(n jsonNode) []jsonNode {
+		if c, ok := n.member(name); ok {
+			return []jsonNode{c}
+		}
+		return nil
+	}
+}
+
+// parseJSONPathFilter parses a filter expression of the form
+// @.some.path == literal.
+func parseJSONPathFilter(expr, filter string) (jsonPathSegment, error) {
+	parts := strings.SplitN(filter, "==", 2)
+	if len(parts) != 2 {
+		return jsonPathSegment{}, fmt.Errorf("unsupported filter expression %q: only equality comparisons are supported", filter)
+	}
+
+	left := strings.TrimSpace(parts[0])
+	right := strings.TrimSpace(parts[1])
+
+	if !strings.HasPrefix(left, "@") {
+		return jsonPathSegment{}, fmt.Errorf("filter expression %q must start with @", filter)
+	}
+
+	memberSegments, err := parseJSONPath("$" + left[1:])
+	if err != nil {
+		return jsonPathSegment{}, err
+	}
+
+	if len(right) > 0 && right[0] == '\'' {
+		s, err := unquoteJSONPathString(right)
+		if err != nil {
+			return jsonPathSegment{}, err
+		}
+		b, _ := json.Marshal(s)
+		right = string(b)
+	}
+
+	literal, err := decodeJSON([]byte(right))
+	if err != nil {
+		return jsonPathSegment{}, fmt.Errorf("invalid literal %q in filter expression: %v", right, err)
+	}
+
+	return jsonPathSegment{
+		expr: expr,
+		selectFn: func(n jsonNode) []jsonNode {
+			var res []jsonNode
+
+		children:
+			for _, c := range n.children() {
+				nodes := []jsonNode{c}
+				for _, seg := range memberSegments {
+					var next []jsonNode
+					for _, m := range nodes {
+						next = append(next, seg.selectFn(m)...)
+					}
+					nodes = next
+				}
+
+				for _, m := range nodes {
+					if len(jsonDiff(literal, m.value, []JSONOpt{NumbersNumerically(true)})) == 0 {
+						res = append(res, c)
+						continue children
+					}
+				}
+			}
+
+			return res
+		},
+	}, nil
+}
+
+func unquoteJSONPathString(s string) (string, error) {
+	if s[0] == '\'' {
+		s = `"` + strings.ReplaceAll(strings.ReplaceAll(s[1:len(s)-1], `\'`, `'`), `"`, `\"`) + `"`
+	}
+
+	var res string
+	if err := json.Unmarshal([]byte(s), &res); err != nil {
+		return "", fmt.Errorf("invalid string %s: %v", s, err)
+	}
+	return res, nil
+}
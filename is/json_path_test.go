@@ -0,0 +1,74 @@
+package is
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+const jsonPathTestDoc = `{
+	"data": [
+		{"id": 1, "name": "foo", "tags": ["a"]},
+		{"id": 2, "name": "bar", "tags": []}
+	],
+	"a/b": {"~c": true}
+}`
+
+func TestJSONAt_pointer(t *testing.T) {
+	var tb testhelper.TB
+
+	JSONAt(jsonPathTestDoc, "/data/0/id", JSONNodeEqualTo(`1`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "/a~1b/~0c", JSONNodeEqualTo(`true`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "/data/1", JSONNodeEqualTo(`{"id": 2, "name": "bar", "tags": []}`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "", func(node any) expect.Expectation {
+		return OfType(node, func(m map[string]any) expect.Expectation { return MapOfLen(m, 2) })
+	}).Expect(&tb)
+
+	JSONAt(jsonPathTestDoc, "/data/1/name", JSONNodeEqualTo(`"foo"`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "/data/0/address/city", JSONNodeEqualTo(`"foo"`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "/data/2", JSONNodeEqualTo(`"foo"`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "data", JSONNodeEqualTo(`"foo"`)).Expect(&tb)
+	JSONAt(`[1]`, "", JSONNodeEqualTo(`[2]`)).Expect(&tb)
+	JSONAt(`{"": 1}`, "/a", JSONNodeEqualTo(`1`)).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"at /data/1/name: JSON values are not equal:\n  want: \"foo\"\n   got: \"bar\"",
+			"path /data/0/address/city does not exist; deepest existing path is /data/0 which is an object with keys [\"id\" \"name\" \"tags\"]",
+			"path /data/2 does not exist; deepest existing path is /data which is an array of len 2",
+			"invalid JSON pointer \"data\": must be empty or start with /",
+			"at (root): JSON values are not equal:\n  at /0\n    want: 2\n     got: 1",
+			"path /a does not exist; deepest existing path is (root) which is an object with keys [\"\"]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestJSONAt_path(t *testing.T) {
+	var tb testhelper.TB
+
+	JSONAt(jsonPathTestDoc, "$.data[0].name", JSONNodeEqualTo(`"foo"`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "$['a/b']['~c']", JSONNodeEqualTo(`true`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "$.data[?(@.name == 'bar')].id", JSONNodeEqualTo(`2`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, `$.data[?(@.id == 1.0)].tags[0]`, JSONNodeEqualTo(`"a"`)).Expect(&tb)
+
+	JSONAt(jsonPathTestDoc, "$.data[*].name", JSONNodeEqualTo(`"foo"`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "$.data[*].tags[0]", JSONNodeEqualTo(`"a"`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "$.data[*].address.city", JSONNodeEqualTo(`"a"`)).Expect(&tb)
+	JSONAt(jsonPathTestDoc, "$..name", JSONNodeEqualTo(`"a"`)).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"at /data/1/name: JSON values are not equal:\n  want: \"foo\"\n   got: \"bar\"",
+			"path $.data[*].address.city does not match any node; deepest matching path is $.data[*] selecting /data/0 which is an object with keys [\"id\" \"name\" \"tags\"], /data/1 which is an object with keys [\"id\" \"name\" \"tags\"]",
+			"invalid JSON path \"$..name\": recursive descent is not supported",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
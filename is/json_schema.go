@@ -0,0 +1,452 @@
+package is
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/halimath/expect"
+)
+
+// JSONMatchingSchema expects the JSON document got to be valid according to the JSON Schema schema. A
+// practical subset of JSON Schema draft 2020-12 is supported:
+//
+//   - type (including integer), enum and const
+//   - properties, required, additionalProperties, minProperties and maxProperties
+//   - items, prefixItems, minItems and maxItems
+//   - pattern, minLength and maxLength
+//   - minimum, maximum, exclusiveMinimum, exclusiveMaximum and multipleOf
+//   - allOf, anyOf, oneOf and not
+//   - $ref referencing the schema document itself (i.e. #/$defs/address)
+//
+// All other keywords are ignored. Every violation is reported with the instance path and the schema path
+// (both given as JSON Pointers) that caused it.
+func JSONMatchingSchema[D, S JSONDocument](got D, schema S) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		s, err := decodeJSON([]byte(schema))
+		if err != nil {
+			t.Errorf("failed to decode JSON schema: %v", err)
+			return
+		}
+
+		validateJSONSchema(t, []byte(got), s)
+	}), got, schema)
+}
+
+// JSONMatchingSchemaFile works like JSONMatchingSchema but reads the schema from the file name in fsys. This
+// allows schemas to be loaded from a testdata directory using os.DirFS or from an embed.FS.
+func JSONMatchingSchemaFile[D JSONDocument](got D, fsys fs.FS, name string) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			t.Errorf("failed to read JSON schema: %v", err)
+			return
+		}
+
+		s, err := decodeJSON(data)
+		if err != nil {
+			t.Errorf("failed to decode JSON schema %s: %v", name, err)
+			return
+		}
+
+		validateJSONSchema(t, []byte(got), s)
+	}), got, fsys, name)
+}
+
+func validateJSONSchema(t expect.TB, got []byte, schema any) {
+	t.Helper()
+
+	doc, err := decodeJSON(got)
+	if err != nil {
+		t.Errorf("failed to decode got as JSON: %v", err)
+		return
+	}
+
+	v := &schemaValidator{root: schema}
+	v.validate(doc, schema, "", "")
+
+	if len(v.violations) == 0 {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("JSON document does not match schema:")
+	for _, vi := range v.violations {
+		b.WriteString(vi.String())
+	}
+	t.Error(b.String())
+}
+
+// schemaViolation describes a single violation of a JSON Schema.
+type schemaViolation struct {
+	instancePath string
+	schemaPath   string
+	msg          string
+}
+
+func (v schemaViolation) String() string {
+	return fmt.Sprintf("\n  at %s (schema %s)\n    %s", displayJSONPointer(v.instancePath), displayJSONPointer(v.schemaPath), v.msg)
+}
+
+type schemaValidator struct {
+	root       any
+	violations []schemaViolation
+	// refDepth guards against infinitely recursive $ref chains.
+	refDepth int
+}
+
+func (v *schemaValidator) addViolation(instancePath, schemaPath, format string, args ...any) {
+	v.violations = append(v.violations, schemaViolation{
+		instancePath: instancePath,
+		schemaPath:   schemaPath,
+		msg:          fmt.Sprintf(format, args...),
+	})
+}
+
+// valid reports whether instance is valid according to schema without
+// recording any violations.
+func (v *schemaValidator) valid(instance, schema any, instancePath, schemaPath string) bool {
+	sub := &schemaValidator{root: v.root, refDepth: v.refDepth}
+	sub.validate(instance, schema, instancePath, schemaPath)
+	return len(sub.violations) == 0
+}
+
+func (v *schemaValidator) validate(instance, schema any, instancePath, schemaPath string) {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			v.addViolation(instancePath, schemaPath, "schema false does not allow any value")
+		}
+		return
+	case map[string]any:
+		v.validateObjectSchema(instance, s, instancePath, schemaPath)
+	default:
+		v.addViolation(instancePath, schemaPath, "invalid schema: expected an object or a boolean but got %s", describeJSONNode(schema))
+	}
+}
+
+func (v *schemaValidator) validateObjectSchema(instance any, s map[string]any, instancePath, schemaPath string) {
+	kw := func(name string) string { return schemaPath + "/" + escapeJSONPointerToken(name) }
+
+	if ref, ok := s["$ref"].(string); ok {
+		v.validateRef(instance, ref, instancePath, kw("$ref"))
+	}
+
+	if typ, ok := s["type"]; ok {
+		v.validateType(instance, typ, instancePath, kw("type"))
+	}
+
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if jsonValuesEqual(e, instance) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.addViolation(instancePath, kw("enum"), "expected one of %s but got %s", formatJSONValues(enum), formatJSONAny(instance))
+		}
+	}
+
+	if c, ok := s["const"]; ok && !jsonValuesEqual(c, instance) {
+		v.addViolation(instancePath, kw("const"), "expected %s but got %s", formatJSONAny(c), formatJSONAny(instance))
+	}
+
+	switch x := instance.(type) {
+	case map[string]any:
+		v.validateObject(x, s, instancePath, schemaPath)
+	case []any:
+		v.validateArray(x, s, instancePath, schemaPath)
+	case string:
+		v.validateString(x, s, instancePath, schemaPath)
+	case json.Number:
+		v.validateNumber(x, s, instancePath, schemaPath)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for i, sub := range all {
+			v.validate(instance, sub, instancePath, kw("allOf")+"/"+strconv.Itoa(i))
+		}
+	}
+
+	if anyOf, ok := s["anyOf"].([]any); ok {
+		matched := false
+		for i, sub := range anyOf {
+			if v.valid(instance, sub, instancePath, kw("anyOf")+"/"+strconv.Itoa(i)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.addViolation(instancePath, kw("anyOf"), "expected value to match at least one schema but it matches none")
+		}
+	}
+
+	if one, ok := s["oneOf"].([]any); ok {
+		var matching []int
+		for i, sub := range one {
+			if v.valid(instance, sub, instancePath, kw("oneOf")+"/"+strconv.Itoa(i)) {
+				matching = append(matching, i)
+			}
+		}
+		if len(matching) != 1 {
+			v.addViolation(instancePath, kw("oneOf"), "expected value to match exactly one schema but it matches %d %v", len(matching), matching)
+		}
+	}
+
+	if not, ok := s["not"]; ok && v.valid(instance, not, instancePath, kw("not")) {
+		v.addViolation(instancePath, kw("not"), "expected value not to match schema")
+	}
+}
+
+func (v *schemaValidator) validateRef(instance any, ref, instancePath, schemaPath string) {
+	if !strings.HasPrefix(ref, "#") {
+		v.addViolation(instancePath, schemaPath, "unsupported $ref %q: only references within the same document are supported", ref)
+		return
+	}
+
+	if v.refDepth > 100 {
+		v.addViolation(instancePath, schemaPath, "$ref %q nested too deeply", ref)
+		return
+	}
+
+	nodes, err := selectJSONPointer(v.root, ref[1:])
+	if err != nil {
+		v.addViolation(instancePath, schemaPath, "unresolvable $ref %q: %v", ref, err)
+		return
+	}
+
+	v.refDepth++
+	v.validate(instance, nodes[0].value, instancePath, schemaPath)
+	v.refDepth--
+}
+
+func (v *schemaValidator) validateType(instance, typ any, instancePath, schemaPath string) {
+	var types []string
+	switch t := typ.(type) {
+	case string:
+		types = []string{t}
+	case []any:
+		for _, e := range t {
+			if s, ok := e.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+
+	actual := jsonTypeOf(instance)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return
+		}
+	}
+
+	if len(types) == 1 {
+		v.addViolation(instancePath, schemaPath, "expected type %s but got %s", types[0], actual)
+	} else {
+		v.addViolation(instancePath, schemaPath, "expected one of types %v but got %s", types, actual)
+	}
+}
+
+func (v *schemaValidator) validateObject(obj map[string]any, s map[string]any, instancePath, schemaPath string) {
+	kw := func(name string) string { return schemaPath + "/" + escapeJSONPointerToken(name) }
+
+	if required, ok := s["required"].([]any); ok {
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, ok := obj[name]; !ok {
+				v.addViolation(instancePath, kw("required"), "missing required property %q", name)
+			}
+		}
+	}
+
+	if n, ok := schemaInt(s["minProperties"]); ok && len(obj) < n {
+		v.addViolation(instancePath, kw("minProperties"), "expected at least %d properties but got %d", n, len(obj))
+	}
+	if n, ok := schemaInt(s["maxProperties"]); ok && len(obj) > n {
+		v.addViolation(instancePath, kw("maxProperties"), "expected at most %d properties but got %d", n, len(obj))
+	}
+
+	properties, _ := s["properties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := instancePath + "/" + escapeJSONPointerToken(k)
+
+		if sub, ok := properties[k]; ok {
+			v.validate(obj[k], sub, childPath, kw("properties")+"/"+escapeJSONPointerToken(k))
+			continue
+		}
+
+		if !hasAdditional {
+			continue
+		}
+
+		if b, ok := additional.(bool); ok {
+			if !b {
+				v.addViolation(childPath, kw("additionalProperties"), "additional property %q is not allowed", k)
+			}
+			continue
+		}
+
+		v.validate(obj[k], additional, childPath, kw("additionalProperties"))
+	}
+}
+
+func (v *schemaValidator) validateArray(arr []any, s map[string]any, instancePath, schemaPath string) {
+	kw := func(name string) string { return schemaPath + "/" + escapeJSONPointerToken(name) }
+
+	if n, ok := schemaInt(s["minItems"]); ok && len(arr) < n {
+		v.addViolation(instancePath, kw("minItems"), "expected at least %d items but got %d", n, len(arr))
+	}
+	if n, ok := schemaInt(s["maxItems"]); ok && len(arr) > n {
+		v.addViolation(instancePath, kw("maxItems"), "expected at most %d items but got %d", n, len(arr))
+	}
+
+	prefixItems, _ := s["prefixItems"].([]any)
+	for i := 0; i < len(prefixItems) && i < len(arr); i++ {
+		v.validate(arr[i], prefixItems[i], instancePath+"/"+strconv.Itoa(i), kw("prefixItems")+"/"+strconv.Itoa(i))
+	}
+
+	if items, ok := s["items"]; ok {
+		for i := len(prefixItems); i < len(arr); i++ {
+			v.validate(arr[i], items, instancePath+"/"+strconv.Itoa(i), kw("items"))
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(str string, s map[string]any, instancePath, schemaPath string) {
+	kw := func(name string) string { return schemaPath + "/" + escapeJSONPointerToken(name) }
+
+	l := utf8.RuneCountInString(str)
+	if n, ok := schemaInt(s["minLength"]); ok && l < n {
+		v.addViolation(instancePath, kw("minLength"), "expected string of at least %d characters but got %d", n, l)
+	}
+	if n, ok := schemaInt(s["maxLength"]); ok && l > n {
+		v.addViolation(instancePath, kw("maxLength"), "expected string of at most %d characters but got %d", n, l)
+	}
+
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			v.addViolation(instancePath, kw("pattern"), "invalid pattern %q: %v", pattern, err)
+		} else if !re.MatchString(str) {
+			v.addViolation(instancePath, kw("pattern"), "expected %q to match pattern %q", str, pattern)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(num json.Number, s map[string]any, instancePath, schemaPath string) {
+	kw := func(name string) string { return schemaPath + "/" + escapeJSONPointerToken(name) }
+
+	val, ok := parseJSONNumber(num)
+	if !ok {
+		return
+	}
+
+	check := func(keyword string, violates func(c int) bool, relation string) {
+		limit, ok := s[keyword].(json.Number)
+		if !ok {
+			return
+		}
+		l, ok := parseJSONNumber(limit)
+		if !ok {
+			return
+		}
+		if violates(val.Cmp(l)) {
+			v.addViolation(instancePath, kw(keyword), "expected number %s %s but got %s", relation, limit, num)
+		}
+	}
+
+	check("minimum", func(c int) bool { return c < 0 }, "greater than or equal to")
+	check("maximum", func(c int) bool { return c > 0 }, "less than or equal to")
+	check("exclusiveMinimum", func(c int) bool { return c <= 0 }, "greater than")
+	check("exclusiveMaximum", func(c int) bool { return c >= 0 }, "less than")
+
+	if m, ok := s["multipleOf"].(json.Number); ok {
+		if d, ok := parseJSONNumber(m); ok && d.Sign() != 0 {
+			q := new(big.Rat).Quo(val, d)
+			if !q.IsInt() {
+				v.addViolation(instancePath, kw("multipleOf"), "expected a multiple of %s but got %s", m, num)
+			}
+		}
+	}
+}
+
+// parseJSONNumber parses n as an exact rational number so that decimal
+// numbers such as 0.1 are represented without rounding.
+func parseJSONNumber(n json.Number) (*big.Rat, bool) {
+	return new(big.Rat).SetString(string(n))
+}
+
+func schemaInt(v any) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(string(n))
+	return i, err == nil
+}
+
+// jsonTypeOf returns the JSON Schema type name of the decoded JSON value v.
+func jsonTypeOf(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if f, ok := parseJSONNumber(x); ok && f.IsInt() {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func jsonValuesEqual(a, b any) bool {
+	return len(jsonDiff(a, b, []JSONOpt{NumbersNumerically(true)})) == 0
+}
+
+func formatJSONAny(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func formatJSONValues(vals []any) string {
+	s := make([]string, len(vals))
+	for i, v := range vals {
+		s[i] = formatJSONAny(v)
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
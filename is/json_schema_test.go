@@ -0,0 +1,105 @@
+package is
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestJSONMatchingSchema(t *testing.T) {
+	var tb testhelper.TB
+
+	schema := `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "exclusiveMinimum": 0},
+			"kind": {"enum": ["a", "b"]},
+			"version": {"const": 2},
+			"price": {"type": "number", "maximum": 10, "multipleOf": 0.5},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 2},
+			"point": {"prefixItems": [{"type": "number"}, {"type": "number"}], "items": false},
+			"value": {"oneOf": [{"type": "string"}, {"type": "integer"}]},
+			"other": {"anyOf": [{"type": "string"}, {"type": "null"}], "not": {"const": "forbidden"}},
+			"extra": {"allOf": [{"type": "string"}, {"maxLength": 3}]}
+		},
+		"additionalProperties": {"type": "boolean"}
+	}`
+
+	JSONMatchingSchema(`{"id": 1, "kind": "a", "version": 2.0, "price": 9.5, "tags": ["x"], "point": [1, 2], "value": 3, "other": null, "extra": "abc", "flag": true}`, schema).Expect(&tb)
+	JSONMatchingSchema(`{"id": 0, "kind": "c", "version": 1, "price": 10.25, "tags": [1, "a", "b"], "point": [1, 2, 3], "value": 1.5, "other": "forbidden", "extra": "abcd", "flag": "yes"}`, schema).Expect(&tb)
+	JSONMatchingSchema(`{`, schema).Expect(&tb)
+	JSONMatchingSchema(`{}`, `{"$ref": "other.json"}`).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"JSON document does not match schema:" +
+				"\n  at /extra (schema /properties/extra/allOf/1/maxLength)\n    expected string of at most 3 characters but got 4" +
+				"\n  at /flag (schema /additionalProperties/type)\n    expected type boolean but got string" +
+				"\n  at /id (schema /properties/id/exclusiveMinimum)\n    expected number greater than 0 but got 0" +
+				"\n  at /kind (schema /properties/kind/enum)\n    expected one of [\"a\", \"b\"] but got \"c\"" +
+				"\n  at /other (schema /properties/other/not)\n    expected value not to match schema" +
+				"\n  at /point/2 (schema /properties/point/items)\n    schema false does not allow any value" +
+				"\n  at /price (schema /properties/price/maximum)\n    expected number less than or equal to 10 but got 10.25" +
+				"\n  at /price (schema /properties/price/multipleOf)\n    expected a multiple of 0.5 but got 10.25" +
+				"\n  at /tags (schema /properties/tags/maxItems)\n    expected at most 2 items but got 3" +
+				"\n  at /tags/0 (schema /properties/tags/items/type)\n    expected type string but got integer" +
+				"\n  at /value (schema /properties/value/oneOf)\n    expected value to match exactly one schema but it matches 0 []" +
+				"\n  at /version (schema /properties/version/const)\n    expected 2 but got 1",
+			"failed to decode got as JSON: unexpected EOF",
+			"JSON document does not match schema:\n  at (root) (schema /$ref)\n    unsupported $ref \"other.json\": only references within the same document are supported",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestJSONMatchingSchema_multipleOf(t *testing.T) {
+	var tb testhelper.TB
+
+	JSONMatchingSchema(`[19.99, 0.3, 4.35, 12.34, 1e-2, 100]`, `{"items": {"multipleOf": 0.01}}`).Expect(&tb)
+	JSONMatchingSchema(`[0.3, 0.7, 12.3, 1e1]`, `{"items": {"multipleOf": 0.1}}`).Expect(&tb)
+	JSONMatchingSchema(`[1.2, 3.5]`, `{"items": {"multipleOf": 1.2e-1}}`).Expect(&tb)
+	JSONMatchingSchema(`[0.005, 1.5]`, `{"items": {"multipleOf": 0.01}}`).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"JSON document does not match schema:" +
+				"\n  at /1 (schema /items/multipleOf)\n    expected a multiple of 1.2e-1 but got 3.5",
+			"JSON document does not match schema:" +
+				"\n  at /0 (schema /items/multipleOf)\n    expected a multiple of 0.01 but got 0.005",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestJSONMatchingSchemaFile(t *testing.T) {
+	var tb testhelper.TB
+
+	fsys := os.DirFS("testdata")
+
+	JSONMatchingSchemaFile(`{"name": "Jane", "age": 32, "address": {"city": "Hamburg", "zip": null}}`, fsys, "person.schema.json").Expect(&tb)
+	JSONMatchingSchemaFile(`{"name": "", "age": 32.5, "email": "jane", "address": {"zip": "123456"}, "phone": "1"}`, fsys, "person.schema.json").Expect(&tb)
+	JSONMatchingSchemaFile(`{}`, fstest.MapFS{}, "missing.json").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"JSON document does not match schema:" +
+				"\n  at /address (schema /properties/address/$ref/required)\n    missing required property \"city\"" +
+				"\n  at /address/zip (schema /properties/address/$ref/properties/zip/maxLength)\n    expected string of at most 5 characters but got 6" +
+				"\n  at /age (schema /properties/age/type)\n    expected type integer but got number" +
+				"\n  at /email (schema /properties/email/pattern)\n    expected \"jane\" to match pattern \"^[^@]+@[^@]+$\"" +
+				"\n  at /name (schema /properties/name/minLength)\n    expected string of at least 1 characters but got 0" +
+				"\n  at /phone (schema /additionalProperties)\n    additional property \"phone\" is not allowed",
+			"failed to read JSON schema: open missing.json: file does not exist",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
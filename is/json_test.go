@@ -0,0 +1,77 @@
+package is
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestJSONEqualTo(t *testing.T) {
+	var tb testhelper.TB
+
+	JSONEqualTo(`{"a": 1, "b": [true, null]}`, []byte(`{"b":[true,null],"a":1}`)).Expect(&tb)
+	JSONEqualTo(json.RawMessage(`{"items": [{"name": "a"}, {"name": "b", "tags": ["x/y"]}]}`), `{"items": [{"name": "a"}, {"name": "c", "tags": {"x/y": 1}}], "count": 2}`).Expect(&tb)
+	JSONEqualTo(`{"a": 1}`, `{"a": "1"}`).Expect(&tb)
+	JSONEqualTo(`{"a": 1`, `{}`).Expect(&tb)
+	JSONEqualTo(`{}`, `{} {}`).Expect(&tb)
+	JSONEqualTo(`{"a/b": {"~c": 1}}`, `{"a/b": {"~c": 2}}`).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"JSON documents are not equal:\n  at /count\n    want: 2\n     got: <missing map key>\n  at /items/1/name\n    want: \"c\"\n     got: \"b\"\n  at /items/1/tags\n    want: {\"x/y\":1}\n     got: [\"x/y\"]",
+			"JSON documents are not equal:\n  at /a\n    want: \"1\"\n     got: 1",
+			"failed to decode got as JSON: unexpected EOF",
+			"failed to decode want as JSON: unexpected data following JSON value at offset 3",
+			"JSON documents are not equal:\n  at /a~1b/~0c\n    want: 2\n     got: 1",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestJSONEqualTo_ignoreArrayOrder(t *testing.T) {
+	var tb testhelper.TB
+
+	JSONEqualTo(`[{"a": 0, "c": 1}, {"b": 1}]`, `[{"b": 1}, {"c": 1}]`, IgnoreArrayOrder(true), IgnoreExtraKeys(true)).Expect(&tb)
+	JSONEqualTo(`[{"a": 1}, {"b": 2}]`, `[{}, {"a": 1}]`, IgnoreArrayOrder(true), IgnoreExtraKeys(true)).Expect(&tb)
+	JSONEqualTo(`[1, 2, 2]`, `[2, 1, 2]`, IgnoreArrayOrder(true)).Expect(&tb)
+
+	JSONEqualTo(`{"items": [{"name": "a"}, {"name": "c"}]}`, `{"items": [{"name": "b"}, {"name": "a"}]}`, IgnoreArrayOrder(true)).Expect(&tb)
+	JSONEqualTo(`[1, 1]`, `[1, 2, 1]`, IgnoreArrayOrder(true)).Expect(&tb)
+	JSONEqualTo(`[1, 2, 3]`, `[3, 1]`, IgnoreArrayOrder(true)).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"JSON documents are not equal:\n  at /items/1/name\n    want: \"b\"\n     got: \"c\"",
+			"JSON documents are not equal:\n  want: 2\n   got: <missing array element>",
+			"JSON documents are not equal:\n  at /1\n    want: <unwanted array element>\n     got: 2",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestJSONEqualTo_options(t *testing.T) {
+	var tb testhelper.TB
+
+	JSONEqualTo(`[3, 1, {"a": [2, 1]}]`, `[{"a": [1, 2]}, 1, 3]`, IgnoreArrayOrder(true)).Expect(&tb)
+	JSONEqualTo(`{"a": 1.0, "b": 1e3, "c": 0.10}`, `{"a": 1, "b": 1000, "c": 0.1}`, NumbersNumerically(true)).Expect(&tb)
+	JSONEqualTo(`{"a": 1, "b": {"c": 2, "d": 3}}`, `{"b": {"c": 2}}`, IgnoreExtraKeys(true)).Expect(&tb)
+
+	JSONEqualTo(`{"a": 1.0}`, `{"a": 1}`).Expect(&tb)
+	JSONEqualTo(`{"b": {"d": 3}}`, `{"a": 1, "b": {"c": 2}}`, IgnoreExtraKeys(true)).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"JSON documents are not equal:\n  at /a\n    want: 1\n     got: 1.0",
+			"JSON documents are not equal:\n  at /a\n    want: 1\n     got: <missing map key>\n  at /b/c\n    want: 2\n     got: <missing map key>",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
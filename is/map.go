@@ -1,10 +1,25 @@
 package is
 
-import "github.com/halimath/expect"
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/halimath/expect"
+)
 
 // MapContaining expects got to contain key with value val.
-func MapContaining[T ~map[K]V, K, V comparable](got T, key K, val V) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+//
+// Comparable values are compared using the go equality operator. Values of non-comparable types (i.e. slices
+// or structs containing maps) are compared using the same algorithm as DeepEqualTo.
+func MapContaining[T ~map[K]V, K comparable, V any](got T, key K, val V) expect.Expectation {
+	eq := elementEquality[V]()
+	if eq == nil {
+		eq = func(a, b V) bool { return deepEquals(a, b) == nil }
+	}
+
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		vg, ok := got[key]
@@ -13,20 +28,209 @@ func MapContaining[T ~map[K]V, K, V comparable](got T, key K, val V) expect.Expe
 			return
 		}
 
-		if vg != val {
+		if !eq(vg, val) {
+			expect.ReportValues(t, vg, val)
 			t.Errorf("expected <%v> to contain key <%v> with value <%v> but got <%v>", got, key, val, vg)
 		}
-	})
+	}), got, key, val)
 }
 
 // MapOfLen expects got to contain want elements.
 func MapOfLen[T ~map[K]V, K comparable, V any](got T, want int) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		gotLen := len(got)
 		if gotLen != want {
+			expect.ReportValues(t, gotLen, want)
 			t.Errorf("expected %v to have len %d but got %d", got, want, gotLen)
 		}
+	}), got, want)
+}
+
+// MapContainingKeys expects got to contain all of keys. Values are not taken into account. Failures list all
+// missing keys in sorted order.
+func MapContainingKeys[T ~map[K]V, K comparable, V any](got T, keys ...K) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var missing []K
+		for _, k := range keys {
+			if _, ok := got[k]; !ok {
+				missing = append(missing, k)
+			}
+		}
+
+		if len(missing) > 0 {
+			t.Errorf("expected <%v> to contain keys %v but these keys do not exist: %v", got, keys, sortKeys(missing))
+		}
+	}), got)
+}
+
+// MapNotContainingKeys expects got to contain none of keys. Failures list all keys present in sorted order.
+func MapNotContainingKeys[T ~map[K]V, K comparable, V any](got T, keys ...K) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var present []K
+		for _, k := range keys {
+			if _, ok := got[k]; ok {
+				present = append(present, k)
+			}
+		}
+
+		if len(present) > 0 {
+			t.Errorf("expected <%v> not to contain keys %v but these keys exist: %v", got, keys, sortKeys(present))
+		}
+	}), got)
+}
+
+// MapSubsetOf expects every entry of got to also be contained in want. Values are compared using the same
+// algorithm as DeepEqualTo customized with opts. want may contain additional entries. Failures list extra
+// entries (those not contained in want) and differing entries separately with keys in sorted order.
+func MapSubsetOf[T ~map[K]V, K comparable, V any](got, want T, opts ...DeepEqualOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		d := diffMaps(got, want, opts)
+		d.missing = nil
+
+		if !d.empty() {
+			t.Errorf("expected map to be a subset of %v:%s", want, d)
+		}
+	}), got, want)
+}
+
+// MapEqualTo expects got and want to contain the same keys with values being deeply equal as defined by
+// DeepEqualTo customized with opts. In contrast to DeepEqualTo, failures are reported key by key in sorted
+// key order listing missing, extra and differing entries separately.
+func MapEqualTo[T ~map[K]V, K comparable, V any](got, want T, opts ...DeepEqualOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if d := diffMaps(got, want, opts); !d.empty() {
+			t.Errorf("maps are not equal:%s", d)
+		}
+	}), got, want)
+}
+
+// MapEvery runs the expectation created by calling f for every entry of got. Entries are visited in sorted
+// key order. All failures are prefixed with the entry's key.
+func MapEvery[T ~map[K]V, K comparable, V any](got T, f func(key K, val V) expect.Expectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		for _, k := range sortedMapKeys(got) {
+			expect.WithMessage(t, "at key [%v]", k).That(f(k, got[k]))
+		}
+	}), got, f)
+}
+
+// mapDiff contains the differences between two maps.
+type mapDiff[K comparable, V any] struct {
+	missing   []K
+	missingV  map[K]V
+	extra     []K
+	extraV    map[K]V
+	differing diff
+}
+
+func (d mapDiff[K, V]) empty() bool {
+	return len(d.missing) == 0 && len(d.extra) == 0 && len(d.differing) == 0
+}
+
+func (d mapDiff[K, V]) String() string {
+	var b strings.Builder
+
+	if len(d.missing) > 0 {
+		b.WriteString("\nmissing entries:")
+		for _, k := range d.missing {
+			fmt.Fprintf(&b, "\n  [%v]: %v", k, d.missingV[k])
+		}
+	}
+
+	if len(d.extra) > 0 {
+		b.WriteString("\nextra entries:")
+		for _, k := range d.extra {
+			fmt.Fprintf(&b, "\n  [%v]: %v", k, d.extraV[k])
+		}
+	}
+
+	if len(d.differing) > 0 {
+		b.WriteString("\ndiffering entries:")
+		b.WriteString(d.differing.String())
+	}
+
+	return b.String()
+}
+
+// diffMaps compares got and want key by key in sorted key order.
+func diffMaps[T ~map[K]V, K comparable, V any](got, want T, opts []DeepEqualOpt) mapDiff[K, V] {
+	d := mapDiff[K, V]{
+		missingV: want,
+		extraV:   got,
+	}
+
+	for _, k := range sortedMapKeys(want) {
+		gv, ok := got[k]
+		if !ok {
+			d.missing = append(d.missing, k)
+			continue
+		}
+
+		for _, e := range deepEquals(want[k], gv, opts...) {
+			e.path = fmt.Sprintf("[%v]%s", k, e.path)
+			d.differing = append(d.differing, e)
+		}
+	}
+
+	for _, k := range sortedMapKeys(got) {
+		if _, ok := want[k]; !ok {
+			d.extra = append(d.extra, k)
+		}
+	}
+
+	return d
+}
+
+func sortedMapKeys[T ~map[K]V, K comparable, V any](m T) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return sortKeys(keys)
+}
+
+// sortKeys sorts keys in place and returns it. Keys of integral, floating
+// point and string kind are sorted by their natural order; all other keys are
+// sorted by their string representation.
+func sortKeys[K comparable](keys []K) []K {
+	sort.Slice(keys, func(i, j int) bool {
+		return lessValue(reflect.ValueOf(keys[i]), reflect.ValueOf(keys[j]))
 	})
+	return keys
+}
+
+func lessValue(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+
+	if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		case reflect.String:
+			return a.String() < b.String()
+		}
+	}
+
+	return fmt.Sprint(a) < fmt.Sprint(b)
 }
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/halimath/expect"
 	"github.com/halimath/expect/internal/testhelper"
 )
 
@@ -42,3 +43,134 @@ func TestMapOfLen(t *testing.T) {
 		t.Errorf("not expected: %#v", tb)
 	}
 }
+
+func TestMapContaining_nonComparable(t *testing.T) {
+	var tb testhelper.TB
+
+	m := map[string][]int{"a": {1}, "b": {2, 3}}
+	MapContaining(m, "b", []int{2, 3}).Expect(&tb)
+	MapContaining(m, "b", []int{2}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected <map[a:[1] b:[2 3]]> to contain key <b> with value <[2]> but got <[2 3]>",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestMapContainingKeys(t *testing.T) {
+	var tb testhelper.TB
+
+	m := map[int]string{1: "a", 2: "b", 10: "c"}
+	MapContainingKeys(m, 1, 10).Expect(&tb)
+	MapContainingKeys(m, 20, 1, 3).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected <map[1:a 2:b 10:c]> to contain keys [20 1 3] but these keys do not exist: [3 20]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestMapNotContainingKeys(t *testing.T) {
+	var tb testhelper.TB
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	MapNotContainingKeys(m, "x", "y").Expect(&tb)
+	MapNotContainingKeys(m, "c", "x", "a").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected <map[a:1 b:2 c:3]> not to contain keys [c x a] but these keys exist: [a c]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestMapSubsetOf(t *testing.T) {
+	var tb testhelper.TB
+
+	want := map[string][]int{"a": {1}, "b": {2}, "c": {3}}
+	MapSubsetOf(map[string][]int{"a": {1}, "c": {3}}, want).Expect(&tb)
+	MapSubsetOf(map[string][]int{"z": {1}, "b": {2, 3}, "a": {0}}, want).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected map to be a subset of map[a:[1] b:[2] c:[3]]:\nextra entries:\n  [z]: [1]\ndiffering entries:\n  at [a][0]\n    want: 1\n     got: 0\n  at [b][1]\n    want: <unwanted slice index>\n     got: 3",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestMapEqualTo(t *testing.T) {
+	var tb testhelper.TB
+
+	want := map[string]float64{"a": 1, "b": 2, "c": 3}
+	MapEqualTo(map[string]float64{"a": 1, "b": 2.0001, "c": 3}, want, FloatPrecision(2)).Expect(&tb)
+	MapEqualTo(map[string]float64{"d": 4, "b": 5, "a": 1}, want).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"maps are not equal:\nmissing entries:\n  [c]: 3\nextra entries:\n  [d]: 4\ndiffering entries:\n  at [b]\n    want: 2.0000000000\n     got: 5.0000000000",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestMapEqualTo_explain(t *testing.T) {
+	var tb testhelper.TB
+
+	cfg := struct{ Labels map[string]int }{Labels: map[string]int{"a": 1}}
+	expect.Explain(&tb).That(MapEqualTo(cfg.Labels, map[string]int{"a": 2}))
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"MapEqualTo(cfg.Labels, map[string]int{\"a\": 2}): maps are not equal:\ndiffering entries:\n  at [a]\n    want: 2\n     got: 1",
+			"MapEqualTo(cfg.Labels, map[string]int{\"a\": 2})\n" +
+				"├── cfg.Labels = map[a:1]\n" +
+				"└── map[string]int{\"a\": 2} = map[a:2]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestMapEvery(t *testing.T) {
+	var tb testhelper.TB
+
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+	MapEvery(m, func(k string, v int) expect.Expectation {
+		return isEven(v)
+	}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"at key [a]: expected 1 to be even",
+			"at key [c]: expected 3 to be even",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func isEven(got int) expect.Expectation {
+	return expect.ExpectFunc(func(t expect.TB) {
+		if got%2 != 0 {
+			t.Errorf("expected %d to be even", got)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/halimath/expect"
+)
+
+// Nil expects got to be nil. got may be of any type that can be nil: pointers, interfaces, slices, maps,
+// channels and functions. Values of all other types are never nil and will fail.
+//
+// If T is an interface type (i.e. error) Nil detects a typed nil value - an interface value holding a nil
+// pointer (or any other nil value) of some concrete type. Such a value is not nil in terms of the go
+// equality operator and thus reported as a failure with an explanation.
+func Nil[T any](got T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		v := reflect.ValueOf(&got).Elem()
+
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return
+			}
+
+			if isNilValue(v.Elem()) {
+				t.Errorf("expected <nil> but got %s", describeTypedNil(v))
+				return
+			}
+
+			t.Errorf("expected <nil> but got %v", got)
+			return
+		}
+
+		if !isNilValue(v) {
+			t.Errorf("expected <nil> but got %v", got)
+		}
+	}), got)
+}
+
+// NotNil expects got not to be nil. See Nil for a description of the supported types. Values of types that
+// cannot be nil always satisfy NotNil.
+func NotNil[T any](got T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		v := reflect.ValueOf(&got).Elem()
+
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				t.Errorf("expected non-nil %s but got <nil>", v.Type())
+			}
+			return
+		}
+
+		if isNilValue(v) {
+			t.Errorf("expected non-nil %s but got <nil>", v.Type())
+		}
+	}), got)
+}
+
+// Zero expects got to be the zero value of type T.
+func Zero[T any](got T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		v := reflect.ValueOf(&got).Elem()
+		if v.IsZero() {
+			return
+		}
+
+		if v.Kind() == reflect.Interface && isNilValue(v.Elem()) {
+			t.Errorf("expected zero value of %s but got %s", v.Type(), describeTypedNil(v))
+			return
+		}
+
+		t.Errorf("expected zero value of %s but got %v", v.Type(), got)
+	}), got)
+}
+
+// NonZero expects got not to be the zero value of type T.
+func NonZero[T any](got T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		v := reflect.ValueOf(&got).Elem()
+		if v.IsZero() {
+			t.Errorf("expected non-zero value of %s but got %v", v.Type(), got)
+		}
+	}), got)
+}
+
+// Empty expects got to have a length of zero. got must be a string, slice, array, map or channel or a pointer
+// to one of these. nil values are considered empty.
+func Empty[T any](got T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		l, ok := lenOf(reflect.ValueOf(got))
+		if !ok {
+			t.Errorf("expected %T to be empty but its length cannot be determined", got)
+			return
+		}
+
+		if l != 0 {
+			t.Errorf("expected %T to be empty but got len %d: %v", got, l, got)
+		}
+	}), got)
+}
+
+// NotEmpty expects got to have a length greater than zero. See Empty for a description of the supported
+// types.
+func NotEmpty[T any](got T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		l, ok := lenOf(reflect.ValueOf(got))
+		if !ok {
+			t.Errorf("expected %T not to be empty but its length cannot be determined", got)
+			return
+		}
+
+		if l == 0 {
+			t.Errorf("expected %T not to be empty", got)
+		}
+	}), got)
+}
+
+// isNilValue reports whether v is nil. In contrast to reflect.Value.IsNil
+// isNilValue never panics and returns true for the invalid value.
+func isNilValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// describeTypedNil describes the interface value v which holds a nil value of
+// some concrete type.
+func describeTypedNil(v reflect.Value) string {
+	return fmt.Sprintf("a non-nil %s holding a nil %s (typed nil); an interface value is only nil if both its type and value are nil", v.Type(), v.Elem().Type())
+}
+
+// lenOf returns the length of v and true if v's kind supports len. Pointers
+// are followed.
+func lenOf(v reflect.Value) (int, bool) {
+	if !v.IsValid() {
+		return 0, true
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return 0, true
+		}
+		return lenOf(v.Elem())
+
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return v.Len(), true
+
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,159 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestNil(t *testing.T) {
+	var tb testhelper.TB
+
+	var p *int
+	var s []int
+	var m map[string]int
+	var c chan int
+	var f func()
+	var err error
+	var typedNil error = (*typedNilError)(nil)
+	i := 1
+
+	Nil(p).Expect(&tb)
+	Nil(s).Expect(&tb)
+	Nil(m).Expect(&tb)
+	Nil(c).Expect(&tb)
+	Nil(f).Expect(&tb)
+	Nil(err).Expect(&tb)
+	Nil[any](nil).Expect(&tb)
+
+	Nil(&i).Expect(&tb)
+	Nil([]int{}).Expect(&tb)
+	Nil(typedNil).Expect(&tb)
+	Nil("foo").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			fmt.Sprintf("expected <nil> but got %v", &i),
+			"expected <nil> but got []",
+			"expected <nil> but got a non-nil error holding a nil *is.typedNilError (typed nil); an interface value is only nil if both its type and value are nil",
+			"expected <nil> but got foo",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestNotNil(t *testing.T) {
+	var tb testhelper.TB
+
+	var p *int
+	var err error
+	i := 1
+
+	NotNil(&i).Expect(&tb)
+	NotNil(1).Expect(&tb)
+	NotNil(map[string]int{}).Expect(&tb)
+	NotNil(p).Expect(&tb)
+	NotNil(err).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected non-nil *int but got <nil>",
+			"expected non-nil error but got <nil>",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestZero(t *testing.T) {
+	var tb testhelper.TB
+
+	type s struct {
+		A int
+		B string
+	}
+
+	var typedNil error = (*typedNilError)(nil)
+
+	Zero(0).Expect(&tb)
+	Zero(s{}).Expect(&tb)
+	Zero(1).Expect(&tb)
+	Zero(s{B: "b"}).Expect(&tb)
+	Zero(typedNil).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected zero value of int but got 1",
+			"expected zero value of is.s but got {0 b}",
+			"expected zero value of error but got a non-nil error holding a nil *is.typedNilError (typed nil); an interface value is only nil if both its type and value are nil",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestNonZero(t *testing.T) {
+	var tb testhelper.TB
+
+	NonZero("a").Expect(&tb)
+	NonZero("").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected non-zero value of string but got ",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	var tb testhelper.TB
+
+	arr := [0]int{}
+
+	Empty("").Expect(&tb)
+	Empty([]int(nil)).Expect(&tb)
+	Empty(map[string]int{}).Expect(&tb)
+	Empty(make(chan int, 1)).Expect(&tb)
+	Empty(&arr).Expect(&tb)
+	Empty("foo").Expect(&tb)
+	Empty([]int{1}).Expect(&tb)
+	Empty(17).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected string to be empty but got len 3: foo",
+			"expected []int to be empty but got len 1: [1]",
+			"expected int to be empty but its length cannot be determined",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestNotEmpty(t *testing.T) {
+	var tb testhelper.TB
+
+	NotEmpty("foo").Expect(&tb)
+	NotEmpty(map[string]int(nil)).Expect(&tb)
+	NotEmpty(17).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected map[string]int not to be empty",
+			"expected int not to be empty but its length cannot be determined",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
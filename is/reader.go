@@ -0,0 +1,226 @@
+package is
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/halimath/expect"
+)
+
+// readerChunkSize defines the size of chunks read from readers.
+const readerChunkSize = 32 * 1024
+
+// readerMaxLineLen defines the maximum length of a line (including the line
+// terminator) read by ReaderLines.
+const readerMaxLineLen = 1024 * 1024
+
+// readerSnippetLen defines the maximum number of bytes shown around a
+// difference.
+const readerSnippetLen = 20
+
+// ReaderContent expects the content read from r to be equal to want. The content is read and compared
+// incrementally in chunks so memory consumption is bounded regardless of the amount of data being read.
+// Failures report the byte offset as well as the line and column of the first difference. Errors returned
+// from r are reported as failures.
+func ReaderContent[W ~string | ~[]byte](r io.Reader, want W) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		wantReader := bytes.NewReader([]byte(want))
+		gotBuf := make([]byte, readerChunkSize)
+		wantBuf := make([]byte, readerChunkSize)
+
+		var pos readerPosition
+
+		for {
+			gn, gerr := io.ReadFull(r, gotBuf)
+			wn, _ := io.ReadFull(wantReader, wantBuf)
+
+			if gerr != nil && !errors.Is(gerr, io.EOF) && !errors.Is(gerr, io.ErrUnexpectedEOF) {
+				t.Errorf("failed to read content at offset %d: %v", pos.offset+int64(gn), gerr)
+				return
+			}
+
+			l := min(gn, wn)
+			for i := 0; i < l; i++ {
+				if gotBuf[i] != wantBuf[i] {
+					t.Errorf("content differs at offset %d (line %d, column %d)\nwant: %q\ngot:  %q", pos.offset, pos.line(), pos.column(), snippet(wantBuf[i:wn]), snippet(gotBuf[i:gn]))
+					return
+				}
+				pos.advance(gotBuf[i])
+			}
+
+			if gn > wn {
+				t.Errorf("content differs at offset %d (line %d, column %d)\nwant: <EOF>\ngot:  %q", pos.offset, pos.line(), pos.column(), snippet(gotBuf[l:gn]))
+				return
+			}
+
+			if wn > gn {
+				t.Errorf("content differs at offset %d (line %d, column %d)\nwant: %q\ngot:  <EOF>", pos.offset, pos.line(), pos.column(), snippet(wantBuf[l:wn]))
+				return
+			}
+
+			if gerr != nil {
+				return
+			}
+		}
+	}), r, want)
+}
+
+// ReaderContaining expects the content read from r to contain want. The content is searched incrementally so
+// memory consumption is bounded by the length of want. Reading stops as soon as want has been found.
+func ReaderContaining[W ~string | ~[]byte](r io.Reader, want W) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		w := []byte(want)
+		if len(w) == 0 {
+			return
+		}
+
+		buf := make([]byte, len(w)-1+readerChunkSize)
+		// kept is the number of bytes kept from the previous chunk to find
+		// occurrences spanning chunk boundaries.
+		kept := 0
+		var total int64
+
+		for {
+			n, err := r.Read(buf[kept:])
+			total += int64(n)
+
+			if bytes.Contains(buf[:kept+n], w) {
+				return
+			}
+
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Errorf("failed to read content at offset %d: %v", total, err)
+					return
+				}
+				t.Errorf("expected content to contain %q but it does not (read %d bytes)", w, total)
+				return
+			}
+
+			end := kept + n
+			kept = min(len(w)-1, end)
+			copy(buf, buf[end-kept:end])
+		}
+	}), r, want)
+}
+
+// ReaderLines expects the content read from r to consist of exactly len(matchers) lines and runs the
+// expectation created by calling matchers[i] with the i-th line (without the line terminator). Failures are
+// prefixed with the line number (starting with 1). Lines are read one at a time, so memory consumption is
+// bounded by the length of the longest line. Lines longer than 1 MiB (including the line terminator) are
+// reported as failures.
+func ReaderLines(r io.Reader, matchers ...func(line string) expect.Expectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		br := bufio.NewReaderSize(r, readerChunkSize)
+		count := 0
+		var buf []byte
+
+		for {
+			buf = buf[:0]
+
+			// Read the line in chunks of at most the reader's buffer size to
+			// enforce readerMaxLineLen while reading.
+			var err error
+			for {
+				var chunk []byte
+				chunk, err = br.ReadSlice('\n')
+				if len(buf)+len(chunk) > readerMaxLineLen {
+					t.Errorf("line %d exceeds the maximum line length of %d bytes", count+1, readerMaxLineLen)
+					return
+				}
+				buf = append(buf, chunk...)
+
+				if !errors.Is(err, bufio.ErrBufferFull) {
+					break
+				}
+			}
+
+			if err != nil && !errors.Is(err, io.EOF) {
+				t.Errorf("failed to read line %d: %v", count+1, err)
+				return
+			}
+
+			if len(buf) > 0 {
+				count++
+				line := trimLineEnding(string(buf))
+
+				if count <= len(matchers) {
+					expect.WithMessage(t, "line %d", count).That(matchers[count-1](line))
+				}
+			}
+
+			if err != nil {
+				break
+			}
+		}
+
+		if count != len(matchers) {
+			t.Errorf("expected %d lines but got %d", len(matchers), count)
+		}
+	}), r)
+}
+
+// ReaderEOFAfter expects r to deliver exactly n bytes followed by io.EOF. The content is discarded.
+func ReaderEOFAfter(r io.Reader, n int64) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		read, err := io.Copy(io.Discard, r)
+		if err != nil {
+			t.Errorf("failed to read content at offset %d: %v", read, err)
+			return
+		}
+
+		if read != n {
+			t.Errorf("expected EOF after %d bytes but got EOF after %d bytes", n, read)
+		}
+	}), r, n)
+}
+
+func trimLineEnding(line string) string {
+	if len(line) > 0 && line[len(line)-1] == '\n' {
+		line = line[:len(line)-1]
+		if len(line) > 0 && line[len(line)-1] == '\r' {
+			line = line[:len(line)-1]
+		}
+	}
+	return line
+}
+
+func snippet(b []byte) []byte {
+	if len(b) > readerSnippetLen {
+		return b[:readerSnippetLen]
+	}
+	return b
+}
+
+// readerPosition tracks a position in content being read.
+type readerPosition struct {
+	offset        int64
+	lines         int64
+	lastLineStart int64
+}
+
+func (p *readerPosition) advance(b byte) {
+	p.offset++
+	if b == '\n' {
+		p.lines++
+		p.lastLineStart = p.offset
+	}
+}
+
+func (p *readerPosition) line() int64 {
+	return p.lines + 1
+}
+
+func (p *readerPosition) column() int64 {
+	return p.offset - p.lastLineStart + 1
+}
@@ -0,0 +1,118 @@
+package is
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestReaderContent(t *testing.T) {
+	var tb testhelper.TB
+
+	long := strings.Repeat("0123456789\n", 10000)
+
+	ReaderContent(strings.NewReader(long), long).Expect(&tb)
+	ReaderContent(iotest.OneByteReader(strings.NewReader("foo\nbar")), []byte("foo\nbar")).Expect(&tb)
+	ReaderContent(strings.NewReader(long+"x"), long+"y").Expect(&tb)
+	ReaderContent(strings.NewReader("foo\nbar"), "foo\nbaz").Expect(&tb)
+	ReaderContent(strings.NewReader("foo"), "foo\nbar").Expect(&tb)
+	ReaderContent(strings.NewReader("foo\nbar"), "foo").Expect(&tb)
+	ReaderContent(iotest.TimeoutReader(strings.NewReader("foo")), "foo").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"content differs at offset 110000 (line 10001, column 1)\nwant: \"y\"\ngot:  \"x\"",
+			"content differs at offset 6 (line 2, column 3)\nwant: \"z\"\ngot:  \"r\"",
+			"content differs at offset 3 (line 1, column 4)\nwant: \"\\nbar\"\ngot:  <EOF>",
+			"content differs at offset 3 (line 1, column 4)\nwant: <EOF>\ngot:  \"\\nbar\"",
+			"failed to read content at offset 3: timeout",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestReaderContaining(t *testing.T) {
+	var tb testhelper.TB
+
+	long := strings.Repeat("x", readerChunkSize-2) + "needle" + strings.Repeat("y", readerChunkSize)
+
+	ReaderContaining(strings.NewReader(long), "needle").Expect(&tb)
+	ReaderContaining(iotest.OneByteReader(strings.NewReader("haystack")), "st").Expect(&tb)
+	ReaderContaining(strings.NewReader("haystack"), "").Expect(&tb)
+	ReaderContaining(strings.NewReader("haystack"), "needle").Expect(&tb)
+	ReaderContaining(iotest.ErrReader(errors.New("failed")), "needle").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected content to contain \"needle\" but it does not (read 8 bytes)",
+			"failed to read content at offset 0: failed",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestReaderLines(t *testing.T) {
+	var tb testhelper.TB
+
+	ReaderLines(strings.NewReader("foo\r\nbar\n"),
+		func(l string) expect.Expectation { return EqualTo(l, "foo") },
+		func(l string) expect.Expectation { return StringWithPrefix(l, "b") },
+	).Expect(&tb)
+
+	ReaderLines(strings.NewReader("foo\nbar\nspam"),
+		func(l string) expect.Expectation { return EqualTo(l, "foo") },
+		func(l string) expect.Expectation { return EqualTo(l, "baz") },
+	).Expect(&tb)
+
+	ReaderLines(strings.NewReader("foo"),
+		func(l string) expect.Expectation { return EqualTo(l, "foo") },
+		func(l string) expect.Expectation { return EqualTo(l, "bar") },
+	).Expect(&tb)
+
+	long := strings.Repeat("a", readerMaxLineLen-1)
+	ReaderLines(strings.NewReader("foo\n"+long+"\n"+long+"a\n"),
+		func(l string) expect.Expectation { return EqualTo(l, "foo") },
+		func(l string) expect.Expectation { return StringOfLen(l, readerMaxLineLen-1) },
+		func(l string) expect.Expectation { return StringOfLen(l, readerMaxLineLen) },
+	).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"line 2: values are not equal\nwant: baz\ngot:  bar",
+			"expected 2 lines but got 3",
+			"expected 2 lines but got 1",
+			"line 3 exceeds the maximum line length of 1048576 bytes",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestReaderEOFAfter(t *testing.T) {
+	var tb testhelper.TB
+
+	ReaderEOFAfter(strings.NewReader("foo"), 3).Expect(&tb)
+	ReaderEOFAfter(io.LimitReader(strings.NewReader("foobar"), 4), 3).Expect(&tb)
+	ReaderEOFAfter(iotest.ErrReader(errors.New("failed")), 3).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected EOF after 3 bytes but got EOF after 4 bytes",
+			"failed to read content at offset 0: failed",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
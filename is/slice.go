@@ -1,64 +1,402 @@
 package is
 
 import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
 	"github.com/halimath/expect"
-	"github.com/halimath/expect/internal/set"
 )
 
 // SliceOfLen create an expect.Expectation that expects len(v) == want.
 func SliceOfLen[T ~[]S, S any](v T, want int) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		got := len(v)
 		if got != want {
+			expect.ReportValues(t, got, want)
 			t.Errorf("expected slice with len %d but got slice with len %d: %v", want, got, v)
 		}
-	})
+	}), v, want)
 }
 
 // SliceContaining expects got to be a slice of element type T contain all values given as wants in any order.
 // Duplicates in wants are not considered to be contained multiple times in the given slice.
-func SliceContaining[S ~[]T, T comparable](v S, wants ...T) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+//
+// Elements of comparable types are compared using the go equality operator. For non-comparable element types
+// (i.e. structs containing slices or maps) SliceContaining behaves like SliceDeepContaining using the default
+// options.
+func SliceContaining[S ~[]T, T any](got S, wants ...T) expect.Expectation {
+	eq := elementEquality[T]()
+	if eq == nil {
+		return expect.WithArguments(SliceDeepContaining(got, wants), got)
+	}
+
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		if len(wants) == 0 {
 			return
 		}
 
-		wantsMissing := set.New(wants...)
+		if wantsMissing := missingElements(got, wants, eq); len(wantsMissing) > 0 {
+			t.Errorf("%T does not contain %v", got, wantsMissing)
+		}
+	}), got)
+}
+
+// SliceContainingInOrder expects go to be a slice with element type T containing all values given as wants
+// in the same order they are given as wants.
+//
+// Elements of comparable types are compared using the go equality operator. For non-comparable element types
+// SliceContainingInOrder behaves like SliceDeepContainingInOrder using the default options.
+func SliceContainingInOrder[S ~[]T, T any](got S, wants ...T) expect.Expectation {
+	eq := elementEquality[T]()
+	if eq == nil {
+		return expect.WithArguments(SliceDeepContainingInOrder(got, wants), got)
+	}
 
-		for _, g := range v {
-			wantsMissing.Remove(g)
-			if len(wantsMissing) == 0 {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		// next holds the index of the next wanted element to find.
+		next := 0
+
+		for _, g := range got {
+			if next == len(wants) {
 				return
 			}
+			if eq(g, wants[next]) {
+				next++
+			}
 		}
 
-		t.Errorf("%T does not contain %v", v, wantsMissing.ToSlice())
-	})
+		if next < len(wants) {
+			t.Errorf("%T does not contain %v in order", got, wants[next])
+		}
+	}), got)
 }
 
-// SliceContainingInOrder expects go to be a slice with element type T containing all values given as wants
-// in the same order they are given as wants.
-func SliceContainingInOrder[S ~[]T, T comparable](v S, wants ...T) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+// SliceDeepContaining works like SliceContaining but compares elements using the same algorithm as
+// DeepEqualTo customized with opts. For every wanted element that is missing, the failure message shows the
+// closest matching element from got together with the differences.
+func SliceDeepContaining[S ~[]T, T any](got S, wants []T, opts ...DeepEqualOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		if len(wants) == 0 {
 			return
 		}
 
-		for _, g := range v {
-			if g == wants[0] {
-				wants = wants[1:]
-				if len(wants) == 0 {
-					return
+		eq := func(a, b T) bool { return deepEquals(a, b, opts...) == nil }
+		wantsMissing := missingElementsPairwise(got, wants, eq)
+		if len(wantsMissing) == 0 {
+			return
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%T does not contain %v", got, wantsMissing)
+		for _, w := range wantsMissing {
+			writeClosestMatch(&b, w, got, 0, opts)
+		}
+
+		t.Error(b.String())
+	}), got, wants)
+}
+
+// SliceDeepContainingInOrder works like SliceContainingInOrder but compares elements using the same algorithm
+// as DeepEqualTo customized with opts. The failure message shows the element from got that matches the first
+// missing element closest together with the differences.
+func SliceDeepContainingInOrder[S ~[]T, T any](got S, wants []T, opts ...DeepEqualOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		// next holds the index of the next wanted element to find; offset
+		// holds the index of the first element in got following the last
+		// matched element.
+		next, offset := 0, 0
+
+		for i, g := range got {
+			if next == len(wants) {
+				return
+			}
+			if deepEquals(wants[next], g, opts...) == nil {
+				offset = i + 1
+				next++
+			}
+		}
+
+		if next == len(wants) {
+			return
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%T does not contain %v in order", got, wants[next])
+		writeClosestMatch(&b, wants[next], got[offset:], offset, opts)
+
+		t.Error(b.String())
+	}), got, wants)
+}
+
+// writeClosestMatch writes the element from candidates having the least number of differences to want
+// along with these differences to w. offset is added to the index being reported. If candidates is empty,
+// nothing is written.
+func writeClosestMatch[T any](w io.Writer, want T, candidates []T, offset int, opts []DeepEqualOpt) {
+	closest := -1
+	var closestDiff diff
+
+	for i, c := range candidates {
+		d := deepEquals(want, c, opts...)
+		if closest < 0 || len(d) < len(closestDiff) {
+			closest = i
+			closestDiff = d
+		}
+	}
+
+	if closest < 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nclosest match for %v is %v at index %d:%s", want, candidates[closest], closest+offset, closestDiff)
+}
+
+// elementEquality returns a function that compares two values of type T
+// using the go equality operator. If T is an interface type, values with
+// dynamic types that are not comparable are compared using deepEquals. If T
+// is not comparable at all, elementEquality returns nil.
+func elementEquality[T any]() func(a, b T) bool {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	if typ.Kind() == reflect.Interface {
+		return func(a, b T) bool {
+			ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+			if ta != tb {
+				return false
+			}
+
+			if ta == nil || ta.Comparable() {
+				return any(a) == any(b)
+			}
+
+			return deepEquals(a, b) == nil
+		}
+	}
+
+	if !typ.Comparable() {
+		return nil
+	}
+
+	return func(a, b T) bool { return any(a) == any(b) }
+}
+
+// missingElements returns the elements of wants not contained in got
+// without duplicates. If all elements of wants can be used as map keys, got's
+// elements are looked up in a set. Otherwise, elements are compared pairwise
+// using eq.
+func missingElements[T any](got, wants []T, eq func(a, b T) bool) []T {
+	missing := make(map[any]struct{}, len(wants))
+	for _, w := range wants {
+		if !hashable(w) {
+			return missingElementsPairwise(got, wants, eq)
+		}
+		missing[w] = struct{}{}
+	}
+
+	for _, g := range got {
+		if len(missing) == 0 {
+			return nil
+		}
+		// An element that cannot be used as a map key is of a type that is
+		// not comparable and thus never equal to any of wants.
+		if hashable(g) {
+			delete(missing, g)
+		}
+	}
+
+	res := make([]T, 0, len(missing))
+	for _, w := range wants {
+		if _, ok := missing[w]; ok {
+			res = append(res, w)
+			delete(missing, w)
+		}
+	}
+
+	return res
+}
+
+func missingElementsPairwise[T any](got, wants []T, eq func(a, b T) bool) []T {
+	wantsMissing := uniqueElements(wants, eq)
+
+	for _, g := range got {
+		wantsMissing = removeElement(wantsMissing, g, eq)
+		if len(wantsMissing) == 0 {
+			return nil
+		}
+	}
+
+	return wantsMissing
+}
+
+// hashable reports whether v can be used as a map key.
+func hashable(v any) bool {
+	t := reflect.TypeOf(v)
+	return t == nil || t.Comparable()
+}
+
+// uniqueElements returns a copy of s with all duplicates (in terms of eq)
+// removed. The order of elements is retained.
+func uniqueElements[T any](s []T, eq func(a, b T) bool) []T {
+	res := make([]T, 0, len(s))
+
+outer:
+	for _, v := range s {
+		for _, r := range res {
+			if eq(r, v) {
+				continue outer
+			}
+		}
+		res = append(res, v)
+	}
+
+	return res
+}
+
+// removeElement removes all elements equal to v (in terms of eq) from s and
+// returns the resulting slice. s is modified in place.
+func removeElement[T any](s []T, v T, eq func(a, b T) bool) []T {
+	res := s[:0]
+	for _, e := range s {
+		if !eq(e, v) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// SliceEqualIgnoringOrder expects got to contain exactly the elements given as wants in any order. In contrast
+// to SliceContaining duplicates are significant: each element must be contained in got exactly as often as it
+// is given in wants and got must not contain any other elements. Failures report both missing and unexpected
+// elements together with their counts.
+func SliceEqualIgnoringOrder[S ~[]T, T comparable](got S, wants ...T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var missing, unexpected elementCounts[T]
+
+		counts := make(map[T]int, len(wants))
+		for _, w := range wants {
+			counts[w]++
+		}
+
+		for _, g := range got {
+			if counts[g] > 0 {
+				counts[g]--
+				continue
+			}
+			unexpected.add(g, 1)
+		}
+
+		for _, w := range wants {
+			if c := counts[w]; c > 0 {
+				missing.add(w, c)
+				counts[w] = 0
+			}
+		}
+
+		if len(missing) == 0 && len(unexpected) == 0 {
+			return
+		}
+
+		t.Errorf("expected %T to contain %v ignoring order but got %v%s", got, wants, got, formatElementCounts(missing, unexpected))
+	}), got)
+}
+
+// SliceDeepEqualIgnoringOrder works like SliceEqualIgnoringOrder but compares elements using the same
+// algorithm as DeepEqualTo. Thus, it can be used with slices of non-comparable element types. opts are used
+// to customize the element comparison.
+func SliceDeepEqualIgnoringOrder[S ~[]T, T any](got, want S, opts ...DeepEqualOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var missing, unexpected elementCounts[T]
+
+		matched := make([]bool, len(got))
+
+	wants:
+		for _, w := range want {
+			for i, g := range got {
+				if !matched[i] && deepEquals(w, g, opts...) == nil {
+					matched[i] = true
+					continue wants
 				}
 			}
+			missing.addDeep(w, opts)
 		}
 
-		t.Errorf("%T does not contain %v in order", v, wants[0])
-	})
+		for i, g := range got {
+			if !matched[i] {
+				unexpected.addDeep(g, opts)
+			}
+		}
+
+		if len(missing) == 0 && len(unexpected) == 0 {
+			return
+		}
+
+		t.Errorf("expected %T to contain %v ignoring order but got %v%s", got, want, got, formatElementCounts(missing, unexpected))
+	}), got, want)
+}
+
+// elementCount associates an element with the number of its occurrences.
+type elementCount[T any] struct {
+	val   T
+	count int
+}
+
+// elementCounts is an ordered list of element counts. Elements are kept in
+// the order in which they have been added first.
+type elementCounts[T any] []elementCount[T]
+
+func (e *elementCounts[T]) add(v T, count int) {
+	for i := range *e {
+		if any((*e)[i].val) == any(v) {
+			(*e)[i].count += count
+			return
+		}
+	}
+	*e = append(*e, elementCount[T]{val: v, count: count})
+}
+
+func (e *elementCounts[T]) addDeep(v T, opts []DeepEqualOpt) {
+	for i := range *e {
+		if deepEquals((*e)[i].val, v, opts...) == nil {
+			(*e)[i].count++
+			return
+		}
+	}
+	*e = append(*e, elementCount[T]{val: v, count: 1})
+}
+
+func (e elementCounts[T]) String() string {
+	var b strings.Builder
+	for i, c := range e {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%v (%dx)", c.val, c.count)
+	}
+	return b.String()
+}
+
+func formatElementCounts[T any](missing, unexpected elementCounts[T]) string {
+	var b strings.Builder
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\n  missing:    %s", missing)
+	}
+	if len(unexpected) > 0 {
+		fmt.Fprintf(&b, "\n  unexpected: %s", unexpected)
+	}
+	return b.String()
 }
@@ -0,0 +1,128 @@
+package is
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/halimath/expect"
+)
+
+// Ordered is a constraint that permits any ordered type: any type that supports the operators < <= >= >.
+// It mirrors cmp.Ordered from the standard library which is not available in all go versions supported by
+// this module.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// SliceSorted expects got to be sorted in ascending order. Equal consecutive elements are allowed.
+func SliceSorted[S ~[]T, T Ordered](got S) expect.Expectation {
+	return expect.WithArguments(sliceSorted(got, compareOrdered[T], "in ascending order", false), got)
+}
+
+// SliceStrictlySorted expects got to be sorted in ascending order with no two consecutive elements being
+// equal.
+func SliceStrictlySorted[S ~[]T, T Ordered](got S) expect.Expectation {
+	return expect.WithArguments(sliceSorted(got, compareOrdered[T], "in strictly ascending order", true), got)
+}
+
+// SliceSortedDescending expects got to be sorted in descending order. Equal consecutive elements are
+// allowed.
+func SliceSortedDescending[S ~[]T, T Ordered](got S) expect.Expectation {
+	return expect.WithArguments(sliceSorted(got, reverseCompare(compareOrdered[T]), "in descending order", false), got)
+}
+
+// SliceStrictlySortedDescending expects got to be sorted in descending order with no two consecutive elements
+// being equal.
+func SliceStrictlySortedDescending[S ~[]T, T Ordered](got S) expect.Expectation {
+	return expect.WithArguments(sliceSorted(got, reverseCompare(compareOrdered[T]), "in strictly descending order", true), got)
+}
+
+// SliceSortedFunc expects got to be sorted in ascending order as defined by cmp. cmp must return a negative
+// number if a < b, a positive number if a > b and zero if a == b. Use a reversed comparison to expect
+// descending order.
+func SliceSortedFunc[S ~[]T, T any](got S, cmp func(a, b T) int) expect.Expectation {
+	return expect.WithArguments(sliceSorted(got, cmp, "by the given comparison", false), got, cmp)
+}
+
+// SliceStrictlySortedFunc works like SliceSortedFunc but expects no two consecutive elements to be equal in
+// terms of cmp.
+func SliceStrictlySortedFunc[S ~[]T, T any](got S, cmp func(a, b T) int) expect.Expectation {
+	return expect.WithArguments(sliceSorted(got, cmp, "strictly by the given comparison", true), got, cmp)
+}
+
+func sliceSorted[S ~[]T, T any](got S, cmp func(a, b T) int, order string, strict bool) expect.Expectation {
+	return expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		for i := 1; i < len(got); i++ {
+			c := cmp(got[i-1], got[i])
+			if c > 0 || (strict && c == 0) {
+				t.Errorf("expected %T to be sorted %s but got %v at index %d followed by %v at index %d: %v", got, order, got[i-1], i-1, got[i], i, got)
+				return
+			}
+		}
+	})
+}
+
+// compareOrdered compares a and b the same way cmp.Compare does. A NaN is
+// considered less than any non-NaN and equal to any other NaN.
+func compareOrdered[T Ordered](a, b T) int {
+	aNaN := a != a
+	bNaN := b != b
+
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN || a < b:
+		return -1
+	case bNaN || a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func reverseCompare[T any](cmp func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp(b, a)
+	}
+}
+
+// SliceUnique expects got to contain every element at most once. Failures report every group of duplicates
+// together with the indices of the duplicate elements.
+func SliceUnique[S ~[]T, T comparable](got S) expect.Expectation {
+	return expect.WithArguments(SliceUniqueBy(got, func(v T) T { return v }), got)
+}
+
+// SliceUniqueBy expects the keys derived from got's elements by applying key to be unique. Failures report
+// every group of elements sharing the same key together with their indices.
+func SliceUniqueBy[S ~[]T, T any, K comparable](got S, key func(T) K) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var keys []K
+		indices := make(map[K][]int, len(got))
+
+		for i, v := range got {
+			k := key(v)
+			if _, ok := indices[k]; !ok {
+				keys = append(keys, k)
+			}
+			indices[k] = append(indices[k], i)
+		}
+
+		var b strings.Builder
+		for _, k := range keys {
+			if len(indices[k]) > 1 {
+				fmt.Fprintf(&b, "\n  %v at indices %v", k, indices[k])
+			}
+		}
+
+		if b.Len() > 0 {
+			t.Errorf("expected %T to contain unique elements but got duplicates:%s", got, b.String())
+		}
+	}), got, key)
+}
@@ -0,0 +1,115 @@
+package is
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestSliceSorted(t *testing.T) {
+	var tb testhelper.TB
+
+	SliceSorted([]int{}).Expect(&tb)
+	SliceSorted([]int{1, 2, 2, 3}).Expect(&tb)
+	SliceSorted([]float64{math.NaN(), 1, 2}).Expect(&tb)
+	SliceSorted([]int{1, 3, 2, 1}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected []int to be sorted in ascending order but got 3 at index 1 followed by 2 at index 2: [1 3 2 1]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceStrictlySorted(t *testing.T) {
+	var tb testhelper.TB
+
+	SliceStrictlySorted([]string{"a", "b"}).Expect(&tb)
+	SliceStrictlySorted([]string{"a", "b", "b"}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected []string to be sorted in strictly ascending order but got b at index 1 followed by b at index 2: [a b b]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceSortedDescending(t *testing.T) {
+	var tb testhelper.TB
+
+	SliceSortedDescending([]int{3, 3, 1}).Expect(&tb)
+	SliceSortedDescending([]int{3, 1, 2}).Expect(&tb)
+	SliceStrictlySortedDescending([]int{3, 2, 1}).Expect(&tb)
+	SliceStrictlySortedDescending([]int{3, 3, 1}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected []int to be sorted in descending order but got 1 at index 1 followed by 2 at index 2: [3 1 2]",
+			"expected []int to be sorted in strictly descending order but got 3 at index 0 followed by 3 at index 1: [3 3 1]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceSortedFunc(t *testing.T) {
+	var tb testhelper.TB
+
+	byLen := func(a, b string) int { return len(a) - len(b) }
+
+	SliceSortedFunc([]string{"a", "b", "cc"}, byLen).Expect(&tb)
+	SliceStrictlySortedFunc([]string{"a", "b", "cc"}, byLen).Expect(&tb)
+	SliceSortedFunc([]string{"aa", "b"}, byLen).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected []string to be sorted strictly by the given comparison but got a at index 0 followed by b at index 1: [a b cc]",
+			"expected []string to be sorted by the given comparison but got aa at index 0 followed by b at index 1: [aa b]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceUnique(t *testing.T) {
+	var tb testhelper.TB
+
+	SliceUnique([]int{1, 2, 3}).Expect(&tb)
+	SliceUnique([]int{1, 2, 1, 3, 2, 1}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected []int to contain unique elements but got duplicates:\n  1 at indices [0 2 5]\n  2 at indices [1 4]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceUniqueBy(t *testing.T) {
+	var tb testhelper.TB
+
+	SliceUniqueBy([]string{"a", "B", "c"}, strings.ToLower).Expect(&tb)
+	SliceUniqueBy([]string{"a", "B", "b"}, strings.ToLower).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected []string to contain unique elements but got duplicates:\n  b at indices [1 2]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
@@ -31,10 +31,11 @@ func TestSliceContaining(t *testing.T) {
 	SliceContaining(s, 1, 3).Expect(&tb)
 	SliceContaining(s, 3, 1).Expect(&tb)
 	SliceContaining(s, 1, 5).Expect(&tb)
+	SliceContaining(s, 7, 2, 6, 7).Expect(&tb)
 
 	if !reflect.DeepEqual(tb, testhelper.TB{
 		ErrFlag: true,
-		Logs:    []string{"[]int does not contain [5]"},
+		Logs:    []string{"[]int does not contain [5]", "[]int does not contain [7 6]"},
 	}) {
 		t.Errorf("not expected: %#v", tb)
 	}
@@ -46,12 +47,15 @@ func TestSliceContainingInOrder(t *testing.T) {
 	s := []int{1, 2, 3, 4}
 	SliceContainingInOrder(s).Expect(&tb)
 	SliceContainingInOrder(s, 1, 3).Expect(&tb)
-	SliceContainingInOrder(s, 3, 1).Expect(&tb)
+	reversed := SliceContainingInOrder(s, 3, 1)
+	reversed.Expect(&tb)
+	reversed.Expect(&tb)
 	SliceContainingInOrder(s, 1, 5).Expect(&tb)
 
 	if !reflect.DeepEqual(tb, testhelper.TB{
 		ErrFlag: true,
 		Logs: []string{
+			"[]int does not contain 1 in order",
 			"[]int does not contain 1 in order",
 			"[]int does not contain 5 in order",
 		},
@@ -59,3 +63,117 @@ func TestSliceContainingInOrder(t *testing.T) {
 		t.Errorf("not expected: %#v", tb)
 	}
 }
+
+func TestSliceEqualIgnoringOrder(t *testing.T) {
+	var tb testhelper.TB
+
+	s := []int{1, 2, 2, 3}
+	SliceEqualIgnoringOrder(s, 2, 3, 1, 2).Expect(&tb)
+	SliceEqualIgnoringOrder(s, 1, 2, 3).Expect(&tb)
+	SliceEqualIgnoringOrder(s, 1, 2, 2, 3, 3, 4).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected []int to contain [1 2 3] ignoring order but got [1 2 2 3]\n  unexpected: 2 (1x)",
+			"expected []int to contain [1 2 2 3 3 4] ignoring order but got [1 2 2 3]\n  missing:    3 (1x), 4 (1x)",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceDeepEqualIgnoringOrder(t *testing.T) {
+	var tb testhelper.TB
+
+	s := [][]int{{1}, {2}, {2}, {3, 4}}
+	SliceDeepEqualIgnoringOrder(s, [][]int{{2}, {3, 4}, {1}, {2}}).Expect(&tb)
+	SliceDeepEqualIgnoringOrder(s, [][]int{{1}, {3, 4}, {5}, {5}}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected [][]int to contain [[1] [3 4] [5] [5]] ignoring order but got [[1] [2] [2] [3 4]]\n  missing:    [5] (2x)\n  unexpected: [2] (2x)",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+type sliceTestElement struct {
+	Name string
+	Tags []string
+}
+
+func TestSliceContaining_nonComparable(t *testing.T) {
+	var tb testhelper.TB
+
+	s := []sliceTestElement{{"a", []string{"x"}}, {"b", []string{"y"}}}
+	SliceContaining(s, sliceTestElement{"b", []string{"y"}}).Expect(&tb)
+	SliceContaining(s, sliceTestElement{"b", []string{"z"}}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"[]is.sliceTestElement does not contain [{b [z]}]\nclosest match for {b [z]} is {b [y]} at index 1:\n  at .Tags[0]\n    want: z\n     got: y",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceContaining_interface(t *testing.T) {
+	var tb testhelper.TB
+
+	s := []any{1, "a", []int{1}}
+	SliceContaining(s, []any{"a", []int{1}}...).Expect(&tb)
+	SliceContaining(s, []any{2}...).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"[]interface {} does not contain [2]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceDeepContaining(t *testing.T) {
+	var tb testhelper.TB
+
+	s := [][]float64{{1, 2}, {3.0001, 4}}
+	SliceDeepContaining(s, [][]float64{{3, 4}}, FloatPrecision(2)).Expect(&tb)
+	SliceDeepContaining(s, [][]float64{{3, 4}}).Expect(&tb)
+	SliceDeepContaining([][]float64{}, [][]float64{{3, 4}}).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"[][]float64 does not contain [[3 4]]\nclosest match for [3 4] is [3.0001 4] at index 1:\n  at [0]\n    want: 3.0000000000\n     got: 3.0001000000",
+			"[][]float64 does not contain [[3 4]]",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSliceDeepContainingInOrder(t *testing.T) {
+	var tb testhelper.TB
+
+	s := [][]int{{1}, {2}, {3}, {2, 1}}
+	SliceDeepContainingInOrder(s, [][]int{{1}, {3}}).Expect(&tb)
+	reversed := SliceDeepContainingInOrder(s, [][]int{{2}, {1}})
+	reversed.Expect(&tb)
+	reversed.Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"[][]int does not contain [1] in order\nclosest match for [1] is [3] at index 2:\n  at [0]\n    want: 1\n     got: 3",
+			"[][]int does not contain [1] in order\nclosest match for [1] is [3] at index 2:\n  at [0]\n    want: 1\n     got: 3",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
@@ -1,55 +1,261 @@
 package is
 
 import (
+	"regexp"
+	"sort"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/grapheme"
 )
 
 // StringOfLen expects got to have byte length want.
 func StringOfLen(got string, want int) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		gotLen := len(got)
 		if gotLen != want {
+			expect.ReportValues(t, gotLen, want)
 			t.Errorf("expected %q to have len %d but got %d", got, want, gotLen)
 		}
-	})
+	}), got, want)
+}
+
+// StringOfRuneLen expects got to contain want runes (unicode code points).
+func StringOfRuneLen(got string, want int) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		gotLen := utf8.RuneCountInString(got)
+		if gotLen != want {
+			expect.ReportValues(t, gotLen, want)
+			t.Errorf("expected %q to have %d runes but got %d", got, want, gotLen)
+		}
+	}), got, want)
+}
+
+// StringOfGraphemeLen expects got to contain want user-perceived characters (extended grapheme clusters as
+// defined by Unicode Standard Annex #29). Thus, a letter followed by a combining accent as well as an emoji
+// composed of multiple code points each count as a single character.
+func StringOfGraphemeLen(got string, want int) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		gotLen := grapheme.Count(got)
+		if gotLen != want {
+			expect.ReportValues(t, gotLen, want)
+			t.Errorf("expected %q to have %d grapheme clusters but got %d", got, want, gotLen)
+		}
+	}), got, want)
+}
+
+// EqualFold expects got and want to be equal under simple unicode case folding, i.e. to be equal ignoring
+// case.
+func EqualFold(got, want string) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if !strings.EqualFold(got, want) {
+			t.Errorf("expected %q to equal %q ignoring case", got, want)
+		}
+	}), got, want)
+}
+
+// WhitespaceMode defines how StringEqualIgnoringWhitespace handles whitespace.
+type WhitespaceMode int
+
+const (
+	// WhitespaceCollapse replaces every run of whitespace with a single space
+	// and removes leading and trailing whitespace (see CollapseWhitespace).
+	WhitespaceCollapse WhitespaceMode = iota
+	// WhitespaceStrip removes all whitespace (see StripWhitespace).
+	WhitespaceStrip
+)
+
+// StringEqualIgnoringWhitespace expects got and want to be equal after normalizing whitespace in both strings
+// according to mode.
+func StringEqualIgnoringWhitespace(got, want string, mode WhitespaceMode) expect.Expectation {
+	transform := CollapseWhitespace
+	if mode == WhitespaceStrip {
+		transform = StripWhitespace
+	}
+
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if transform(got) != transform(want) {
+			t.Errorf("expected %q to equal %q ignoring whitespace", got, want)
+		}
+	}), got, want, mode)
+}
+
+// StringEqualIgnoringLineEndings expects got and want to be equal after normalizing line endings in both
+// strings (see NormalizeLineEndings).
+func StringEqualIgnoringLineEndings(got, want string) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if NormalizeLineEndings(got) != NormalizeLineEndings(want) {
+			t.Errorf("expected %q to equal %q ignoring line endings", got, want)
+		}
+	}), got, want)
+}
+
+// FoldCase is intended to be used as a transformer passed to [EqualToStringByLines]. It maps every rune of s
+// to a canonical rune of its case folding orbit making lines comparable ignoring case the same way EqualFold
+// does.
+func FoldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		return folded
+	}, s)
+}
+
+// CollapseWhitespace is intended to be used as a transformer passed to [EqualToStringByLines]. It replaces
+// every run of whitespace in s with a single space and removes leading and trailing whitespace.
+func CollapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// StripWhitespace is intended to be used as a transformer passed to [EqualToStringByLines]. It removes all
+// whitespace from s.
+func StripWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// NormalizeLineEndings converts CRLF and CR line endings in s to LF. It is used by
+// [StringEqualIgnoringLineEndings]. To compare lines using [EqualToStringByLines], which splits strings at
+// LF, use [TrimCarriageReturn] instead.
+func NormalizeLineEndings(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+// TrimCarriageReturn is intended to be used as a transformer passed to [EqualToStringByLines]. It removes a
+// single trailing CR from the line s so that lines of text using CRLF line endings compare equal to lines
+// using LF.
+func TrimCarriageReturn(s string) string {
+	return strings.TrimSuffix(s, "\r")
 }
 
 // StringContaining expects got to be a string containing want as a substring.
 func StringContaining(got, want string) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		if !strings.Contains(got, want) {
 			t.Errorf("expected %q to contain %q", got, want)
 		}
-	})
+	}), got, want)
 }
 
 // StringWithPrefix expects got to be a string having prefix want.
 func StringWithPrefix(got, want string) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		if !strings.HasPrefix(got, want) {
 			t.Errorf("expected %q to have prefix %q", got, want)
 		}
-	})
+	}), got, want)
 }
 
 // StringWithSuffix expects got to be a string having suffix want.
 func StringWithSuffix(got, want string) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		if !strings.HasSuffix(got, want) {
 			t.Errorf("expected %q to have suffix %q", got, want)
 		}
-	})
+	}), got, want)
+}
+
+// StringMatching expects got to match the regular expression pattern. The pattern uses the syntax accepted
+// by the regexp package. An invalid pattern is reported as a failure.
+func StringMatching(got, pattern string) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			t.Errorf("invalid regular expression %q: %v", pattern, err)
+			return
+		}
+
+		if !re.MatchString(got) {
+			t.Errorf("expected %q to match %q", got, pattern)
+		}
+	}), got, pattern)
+}
+
+// StringNotMatching expects got not to match the regular expression pattern. An invalid pattern is reported
+// as a failure.
+func StringNotMatching(got, pattern string) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			t.Errorf("invalid regular expression %q: %v", pattern, err)
+			return
+		}
+
+		if loc := re.FindStringIndex(got); loc != nil {
+			t.Errorf("expected %q not to match %q but found match %q at offset %d", got, pattern, got[loc[0]:loc[1]], loc[0])
+		}
+	}), got, pattern)
+}
+
+// StringMatchingWith expects got to match the regular expression pattern and runs further expectations on
+// the values of named capture groups of the first match. groups maps capture group names to functions
+// creating the expectations for the captured value. Failures of these expectations are prefixed with the
+// group's name. Naming a group in groups that is not defined by pattern is reported as a failure.
+func StringMatchingWith(got, pattern string, groups map[string]func(string) expect.Expectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			t.Errorf("invalid regular expression %q: %v", pattern, err)
+			return
+		}
+
+		match := re.FindStringSubmatch(got)
+		if match == nil {
+			t.Errorf("expected %q to match %q", got, pattern)
+			return
+		}
+
+		names := make([]string, 0, len(groups))
+		for name := range groups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			idx := re.SubexpIndex(name)
+			if idx < 0 {
+				t.Errorf("regular expression %q does not define a capture group named %q", pattern, name)
+				continue
+			}
+
+			expect.WithMessage(t, "capture group %q", name).That(groups[name](match[idx]))
+		}
+	}), got, pattern, groups)
 }
 
 // Dedent is intended to be used as a transformer passed to [EqualToStringByLines].
@@ -68,7 +274,7 @@ func DedentLines(s string) string {
 // transformers are applied in order (iteratively) and the final transformation
 // result is used for comparison.
 func EqualToStringByLines(got, want string, transformers ...func(string) string) expect.Expectation {
-	return expect.ExpectFunc(func(t expect.TB) {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
 		t.Helper()
 
 		gotLines := strings.Split(got, "\n")
@@ -111,7 +317,7 @@ func EqualToStringByLines(got, want string, transformers ...func(string) string)
 			}
 		}
 
-	})
+	}), got, want)
 }
 
 func min(a, b int) int {
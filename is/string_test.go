@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/halimath/expect"
 	"github.com/halimath/expect/internal/testhelper"
 )
 
@@ -96,3 +97,188 @@ func TestEqualToStringByLines(t *testing.T) {
 		t.Errorf("not expected: %#v", tb)
 	}
 }
+
+func TestStringMatching(t *testing.T) {
+	var tb testhelper.TB
+
+	StringMatching("foobar", "^fo+").Expect(&tb)
+	StringMatching("spameggs", "^fo+").Expect(&tb)
+	StringMatching("foobar", "fo(").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected \"spameggs\" to match \"^fo+\"",
+			"invalid regular expression \"fo(\": error parsing regexp: missing closing ): `fo(`",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestStringNotMatching(t *testing.T) {
+	var tb testhelper.TB
+
+	StringNotMatching("spameggs", "o+").Expect(&tb)
+	StringNotMatching("foobar", "o+").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected \"foobar\" not to match \"o+\" but found match \"oo\" at offset 1",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestStringMatchingWith(t *testing.T) {
+	var tb testhelper.TB
+
+	pattern := `level=(?P<level>\w+) msg="(?P<msg>[^"]*)"`
+	line := `time=now level=info msg="request served"`
+
+	StringMatchingWith(line, pattern, map[string]func(string) expect.Expectation{
+		"level": func(s string) expect.Expectation { return EqualTo(s, "info") },
+		"msg":   func(s string) expect.Expectation { return StringContaining(s, "served") },
+	}).Expect(&tb)
+
+	StringMatchingWith(line, pattern, map[string]func(string) expect.Expectation{
+		"level": func(s string) expect.Expectation { return EqualTo(s, "error") },
+		"user":  func(s string) expect.Expectation { return StringOfLen(s, 0) },
+	}).Expect(&tb)
+
+	StringMatchingWith("foo", pattern, nil).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"capture group \"level\": values are not equal\nwant: error\ngot:  info",
+			"regular expression \"level=(?P<level>\\\\w+) msg=\\\"(?P<msg>[^\\\"]*)\\\"\" does not define a capture group named \"user\"",
+			"expected \"foo\" to match \"level=(?P<level>\\\\w+) msg=\\\"(?P<msg>[^\\\"]*)\\\"\"",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestStringOfRuneLen(t *testing.T) {
+	var tb testhelper.TB
+
+	StringOfRuneLen("äöü", 3).Expect(&tb)
+	StringOfRuneLen("äöü", 6).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected \"äöü\" to have 6 runes but got 3",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestStringOfGraphemeLen(t *testing.T) {
+	tests := map[string]int{
+		"":        0,
+		"abc":     3,
+		"e\u0301": 1,
+		"\r\n":    1,
+		"a\r\nb":  3,
+		"\U0001F1E9\U0001F1EA\U0001F1EB\U0001F1F7":                   2,
+		"\U0001F1E9\U0001F1EA\U0001F1EB":                             2,
+		"\U0001F44D\U0001F3FD":                                       1,
+		"\U0001F469\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466": 1,
+		"a\u200D\U0001F466":                                          2,
+		"\u1100\u1161\u11A8":                                         1,
+		"\uD55C\uAD6D\uC5B4":                                         3,
+	}
+
+	for s, want := range tests {
+		var tb testhelper.TB
+		StringOfGraphemeLen(s, want).Expect(&tb)
+		if tb.ErrFlag {
+			t.Errorf("unexpected failure: %v", tb.Logs)
+		}
+	}
+
+	var tb testhelper.TB
+	StringOfGraphemeLen("e\u0301", 2).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected \"e\u0301\" to have 2 grapheme clusters but got 1",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	var tb testhelper.TB
+
+	EqualFold("Straße", "STRAßE").Expect(&tb)
+	EqualFold("foo", "bar").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected \"foo\" to equal \"bar\" ignoring case",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestStringEqualIgnoringWhitespace(t *testing.T) {
+	var tb testhelper.TB
+
+	StringEqualIgnoringWhitespace("  foo \t bar\n", "foo bar", WhitespaceCollapse).Expect(&tb)
+	StringEqualIgnoringWhitespace("foo bar", "foobar", WhitespaceCollapse).Expect(&tb)
+	StringEqualIgnoringWhitespace("f o o\tbar", "foobar", WhitespaceStrip).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected \"foo bar\" to equal \"foobar\" ignoring whitespace",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestStringEqualIgnoringLineEndings(t *testing.T) {
+	var tb testhelper.TB
+
+	StringEqualIgnoringLineEndings("foo\r\nbar\rspam\n", "foo\nbar\nspam\n").Expect(&tb)
+	StringEqualIgnoringLineEndings("x\r", "x\n").Expect(&tb)
+	StringEqualIgnoringLineEndings("foo\r\n\r\nbar", "foo\nbar").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected \"foo\\r\\n\\r\\nbar\" to equal \"foo\\nbar\" ignoring line endings",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestNormalizeLineEndings(t *testing.T) {
+	if got := NormalizeLineEndings("a\rb\r\nc\r"); got != "a\nb\nc\n" {
+		t.Errorf("not expected: %q", got)
+	}
+}
+
+func TestEqualToStringByLines_transformers(t *testing.T) {
+	var tb testhelper.TB
+
+	EqualToStringByLines("Foo  Bar\r\nspam", "foo bar\nSPAM", TrimCarriageReturn, CollapseWhitespace, FoldCase).Expect(&tb)
+	EqualToStringByLines("a b\nc", "ab\nc", StripWhitespace).Expect(&tb)
+	EqualToStringByLines("a\r\nb\r\n", "a\nb\n", TrimCarriageReturn).Expect(&tb)
+
+	if tb.ErrFlag {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
@@ -0,0 +1,285 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/halimath/expect"
+)
+
+// FieldExpectation defines an expectation on a single field selected from a
+// struct. Use Field to create values of this type and Struct to run them.
+type FieldExpectation struct {
+	path     string
+	segments []fieldPathSegment
+	expect   func(t expect.TB, v reflect.Value)
+}
+
+// Field creates a FieldExpectation that selects the value at path and runs the expectation created by
+// calling f with it.
+//
+// path uses the dot notation DeepEqualTo uses in its output and ExcludeFields accepts, i.e.
+// "Address.City", ".Items[2].Name" or ".Labels[env]". The leading dot may be omitted. Pointers and
+// interfaces are dereferenced while walking the path. Only exported fields can be selected. The selected
+// value must be assignable to T; use any to accept values of any type.
+func Field[T any](path string, f func(v T) expect.Expectation) FieldExpectation {
+	return FieldExpectation{
+		path:     path,
+		segments: parseFieldPath(path),
+		expect: func(t expect.TB, v reflect.Value) {
+			t.Helper()
+
+			target := reflect.TypeOf((*T)(nil)).Elem()
+			if !v.Type().AssignableTo(target) {
+				t.Errorf("expected value of type %s but got %s", target, v.Type())
+				return
+			}
+
+			// Going through a pointer avoids a failing type assertion for
+			// nil interface values.
+			typed := reflect.New(target)
+			typed.Elem().Set(v)
+
+			f(*typed.Interface().(*T)).Expect(t)
+		},
+	}
+}
+
+// Struct expects got to be a struct (or a pointer to a struct) and runs all field expectations on it.
+// Failures are prefixed with the field's path. Paths that cannot be resolved are reported as failures
+// suggesting similar field names.
+func Struct(got any, fields ...FieldExpectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		root := reflect.ValueOf(got)
+		for root.IsValid() && (root.Kind() == reflect.Ptr || root.Kind() == reflect.Interface) && !root.IsNil() {
+			root = root.Elem()
+		}
+
+		if !root.IsValid() || root.Kind() != reflect.Struct {
+			t.Errorf("expected a struct but got %#v", got)
+			return
+		}
+
+		for _, field := range fields {
+			e := expect.WithMessage(t, "%s", field.path)
+
+			v, err := resolveFieldPath(root, field.segments)
+			if err != nil {
+				e.That(expect.ExpectFunc(func(t expect.TB) {
+					t.Helper()
+					t.Error(err.Error())
+				}))
+				continue
+			}
+
+			f := field
+			e.That(expect.ExpectFunc(func(t expect.TB) {
+				t.Helper()
+				f.expect(t, v)
+			}))
+		}
+	}), got)
+}
+
+// fieldPathSegment is a single segment of a field path. It either selects a
+// named struct field or - if index is true - a slice or array index or a map
+// key.
+type fieldPathSegment struct {
+	name  string
+	index bool
+}
+
+func (s fieldPathSegment) String() string {
+	if s.index {
+		return "[" + s.name + "]"
+	}
+	return "." + s.name
+}
+
+// parseFieldPath splits path into its segments.
+func parseFieldPath(path string) []fieldPathSegment {
+	var segments []fieldPathSegment
+
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			continue
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, fieldPathSegment{name: path[1:end], index: true})
+			if end < len(path) {
+				end++
+			}
+			path = path[end:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, fieldPathSegment{name: path[:end]})
+			path = path[end:]
+		}
+	}
+
+	return segments
+}
+
+// resolveFieldPath walks segments starting at v and returns the selected
+// value.
+func resolveFieldPath(v reflect.Value, segments []fieldPathSegment) (reflect.Value, error) {
+	var resolved strings.Builder
+
+	for _, seg := range segments {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Errorf("cannot select %s: %s is nil", seg, describeResolvedPath(resolved.String()))
+			}
+			v = v.Elem()
+		}
+
+		if seg.index {
+			switch v.Kind() {
+			case reflect.Slice, reflect.Array:
+				i, err := strconv.Atoi(seg.name)
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("invalid index %s for %s of type %s", seg, describeResolvedPath(resolved.String()), v.Type())
+				}
+				if i < 0 || i >= v.Len() {
+					return reflect.Value{}, fmt.Errorf("index %s out of range for %s of length %d", seg, describeResolvedPath(resolved.String()), v.Len())
+				}
+				v = v.Index(i)
+
+			case reflect.Map:
+				key, ok := findMapKey(v, seg.name)
+				if !ok {
+					return reflect.Value{}, fmt.Errorf("no key %s in %s", seg, describeResolvedPath(resolved.String()))
+				}
+				v = v.MapIndex(key)
+
+			default:
+				return reflect.Value{}, fmt.Errorf("cannot select %s from %s of type %s", seg, describeResolvedPath(resolved.String()), v.Type())
+			}
+		} else {
+			if v.Kind() != reflect.Struct {
+				return reflect.Value{}, fmt.Errorf("cannot select %s from %s of type %s", seg, describeResolvedPath(resolved.String()), v.Type())
+			}
+
+			f, ok := v.Type().FieldByName(seg.name)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("no field %s in %s%s", seg.name, v.Type(), suggestFieldNames(v.Type(), seg.name))
+			}
+			if !f.IsExported() {
+				return reflect.Value{}, fmt.Errorf("cannot select unexported field %s of %s", seg.name, v.Type())
+			}
+			for i, idx := range f.Index {
+				if i > 0 && v.Kind() == reflect.Ptr {
+					if v.IsNil() {
+						return reflect.Value{}, fmt.Errorf("cannot select %s: embedded %s is nil", seg, v.Type())
+					}
+					v = v.Elem()
+				}
+				v = v.Field(idx)
+			}
+		}
+
+		resolved.WriteString(seg.String())
+	}
+
+	return v, nil
+}
+
+func describeResolvedPath(p string) string {
+	if p == "" {
+		return "value"
+	}
+	return p
+}
+
+// findMapKey returns the key of m which formats as name.
+func findMapKey(m reflect.Value, name string) (reflect.Value, bool) {
+	for _, k := range m.MapKeys() {
+		if fmt.Sprint(k) == name {
+			return k, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// suggestFieldNames returns a hint listing the fields of t with names
+// similar to name or listing all fields if none is similar.
+func suggestFieldNames(t reflect.Type, name string) string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	threshold := len(name) / 3
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	var names []string
+	var candidates []candidate
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		names = append(names, f.Name)
+
+		d := editDistance(strings.ToLower(f.Name), strings.ToLower(name))
+		if d <= threshold {
+			candidates = append(candidates, candidate{f.Name, d})
+		}
+	}
+
+	if len(candidates) > 0 {
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+		similar := make([]string, len(candidates))
+		for i, c := range candidates {
+			similar[i] = c.name
+		}
+		return "; did you mean " + strings.Join(similar, " or ") + "?"
+	}
+
+	if len(names) == 0 {
+		return "; it has no exported fields"
+	}
+
+	return "; available fields are " + strings.Join(names, ", ")
+}
+
+// editDistance calculates the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(min(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
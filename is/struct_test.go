@@ -0,0 +1,175 @@
+package is
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+type structTestAddress struct {
+	Street string
+	City   string
+}
+
+type structTestPerson struct {
+	Name    string
+	Address *structTestAddress
+	Tags    []string
+	Labels  map[string]int
+	Any     any
+	secret  string
+}
+
+func TestStruct(t *testing.T) {
+	p := structTestPerson{
+		Name:    "Alice",
+		Address: &structTestAddress{Street: "Main St", City: "Berlin"},
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]int{"env": 1},
+		Any:     structTestAddress{City: "Paris"},
+		secret:  "s",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var tb testhelper.TB
+
+		Struct(&p,
+			Field("Name", func(v string) expect.Expectation { return EqualTo(v, "Alice") }),
+			Field("Address.City", func(v string) expect.Expectation { return EqualTo(v, "Berlin") }),
+			Field(".Tags[1]", func(v string) expect.Expectation { return EqualTo(v, "b") }),
+			Field(".Labels[env]", func(v int) expect.Expectation { return EqualTo(v, 1) }),
+			Field("Any.City", func(v any) expect.Expectation { return DeepEqualTo(v, any("Paris")) }),
+		).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		var tb testhelper.TB
+
+		Struct(p,
+			Field("Address.City", func(v string) expect.Expectation { return EqualTo(v, "Hamburg") }),
+			Field("Address.Cty", func(v string) expect.Expectation { return EqualTo(v, "Hamburg") }),
+			Field("Adress.City", func(v string) expect.Expectation { return EqualTo(v, "Hamburg") }),
+			Field("Zip", func(v string) expect.Expectation { return EqualTo(v, "12345") }),
+			Field("Tags[2]", func(v string) expect.Expectation { return EqualTo(v, "c") }),
+			Field("Tags[x]", func(v string) expect.Expectation { return EqualTo(v, "c") }),
+			Field("Labels[stage]", func(v int) expect.Expectation { return EqualTo(v, 1) }),
+			Field("Name.First", func(v string) expect.Expectation { return EqualTo(v, "A") }),
+			Field("secret", func(v string) expect.Expectation { return EqualTo(v, "s") }),
+			Field("Name", func(v int) expect.Expectation { return EqualTo(v, 1) }),
+		).Expect(&tb)
+
+		Struct(structTestPerson{}, Field("Address.City", func(v string) expect.Expectation { return EqualTo(v, "") })).Expect(&tb)
+		Struct(17, Field("Name", func(v string) expect.Expectation { return EqualTo(v, "") })).Expect(&tb)
+
+		if !reflect.DeepEqual(tb, testhelper.TB{
+			ErrFlag: true,
+			Logs: []string{
+				"Address.City: values are not equal\nwant: Hamburg\ngot:  Berlin",
+				"Address.Cty: no field Cty in is.structTestAddress; did you mean City?",
+				"Adress.City: no field Adress in is.structTestPerson; did you mean Address?",
+				"Zip: no field Zip in is.structTestPerson; available fields are Name, Address, Tags, Labels, Any",
+				"Tags[2]: index [2] out of range for .Tags of length 2",
+				"Tags[x]: invalid index [x] for .Tags of type []string",
+				"Labels[stage]: no key [stage] in .Labels",
+				"Name.First: cannot select .First from .Name of type string",
+				"secret: cannot select unexported field secret of is.structTestPerson",
+				"Name: expected value of type int but got string",
+				"Address.City: cannot select .City: .Address is nil",
+				"expected a struct but got 17",
+			},
+		}) {
+			t.Errorf("not expected: %#v", tb)
+		}
+	})
+}
+
+type structTestBase struct {
+	ID string
+}
+
+type structTestEmbedding struct {
+	*structTestBase
+}
+
+func TestStruct_embedded(t *testing.T) {
+	var tb testhelper.TB
+
+	Struct(structTestEmbedding{structTestBase: &structTestBase{ID: "a"}},
+		Field("ID", func(v string) expect.Expectation { return EqualTo(v, "a") }),
+	).Expect(&tb)
+	Struct(structTestEmbedding{},
+		Field("ID", func(v string) expect.Expectation { return EqualTo(v, "a") }),
+	).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"ID: cannot select .ID: embedded *is.structTestBase is nil",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestStruct_nilInterfaces(t *testing.T) {
+	type result struct {
+		Err   error
+		Value any
+	}
+
+	var tb testhelper.TB
+
+	Struct(result{},
+		Field("Err", NoError),
+		Field("Value", func(v any) expect.Expectation { return Nil(v) }),
+	).Expect(&tb)
+	Struct(result{Err: io.EOF},
+		Field("Err", NoError),
+	).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"Err: expected no error but got \"EOF\"",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestParseFieldPath(t *testing.T) {
+	got := parseFieldPath(".Items[2].Labels[env].Name")
+	want := []fieldPathSegment{
+		{name: "Items"},
+		{name: "2", index: true},
+		{name: "Labels"},
+		{name: "env", index: true},
+		{name: "Name"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("not expected: %#v", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := map[[2]string]int{
+		{"", ""}:              0,
+		{"city", "cty"}:       1,
+		{"address", "adress"}: 1,
+		{"kitten", "sitting"}: 3,
+	}
+
+	for in, want := range tests {
+		if got := editDistance(in[0], in[1]); got != want {
+			t.Errorf("editDistance(%q, %q): expected %d but got %d", in[0], in[1], want, got)
+		}
+	}
+}
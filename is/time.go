@@ -0,0 +1,126 @@
+package is
+
+import (
+	"math"
+	"time"
+
+	"github.com/halimath/expect"
+)
+
+// TimeEqualTo expects got and want to represent the same instant in time as defined by time.Time.Equal. In
+// contrast to EqualTo or DeepEqualTo, the location and the monotonic clock reading are not taken into
+// account.
+func TimeEqualTo(got, want time.Time) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if !got.Equal(want) {
+			expect.ReportValues(t, got, want)
+			t.Errorf("expected time %s but got %s (difference %s)", formatTime(want), formatTime(got), formatTimeDifference(got, want))
+		}
+	}), got, want)
+}
+
+// TimeWithin expects got to differ from want by no more than tolerance (in either direction).
+func TimeWithin(got, want time.Time, tolerance time.Duration) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if !timeWithin(got, want, tolerance) {
+			t.Errorf("expected time %s to be within %s of %s but difference is %s", formatTime(got), tolerance, formatTime(want), formatTimeDifference(got, want))
+		}
+	}), got, want, tolerance)
+}
+
+// TimeBefore expects got to be before want.
+func TimeBefore(got, want time.Time) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if !got.Before(want) {
+			t.Errorf("expected time %s to be before %s", formatTime(got), formatTime(want))
+		}
+	}), got, want)
+}
+
+// TimeAfter expects got to be after want.
+func TimeAfter(got, want time.Time) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if !got.After(want) {
+			t.Errorf("expected time %s to be after %s", formatTime(got), formatTime(want))
+		}
+	}), got, want)
+}
+
+// TimeBetween expects got to be between start and end (both inclusive).
+func TimeBetween(got, start, end time.Time) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if got.Before(start) || got.After(end) {
+			t.Errorf("expected time %s to be between %s and %s", formatTime(got), formatTime(start), formatTime(end))
+		}
+	}), got, start, end)
+}
+
+// TimeInLocation expects got's location to be want. Locations are compared by name.
+func TimeInLocation(got time.Time, want *time.Location) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if got.Location().String() != want.String() {
+			t.Errorf("expected time %s to be in location %s but got %s", formatTime(got), want, got.Location())
+		}
+	}), got, want)
+}
+
+// DurationWithin expects got to differ from want by no more than tolerance (in either direction).
+func DurationWithin(got, want, tolerance time.Duration) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		d, ok := durationDifference(got, want)
+		if !ok {
+			t.Errorf("expected duration %s to be within %s of %s but difference exceeds %s", got, tolerance, want, time.Duration(math.MaxInt64))
+		} else if d < -tolerance || d > tolerance {
+			t.Errorf("expected duration %s to be within %s of %s but difference is %s", got, tolerance, want, d)
+		}
+	}), got, want, tolerance)
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// timeWithin reports whether got differs from want by no more than
+// tolerance. In contrast to using time.Time.Sub, this works for instants
+// whose difference exceeds the range of time.Duration.
+func timeWithin(got, want time.Time, tolerance time.Duration) bool {
+	return !got.Before(want.Add(-tolerance)) && !got.After(want.Add(tolerance))
+}
+
+// formatTimeDifference formats the difference between got and want. As
+// time.Time.Sub saturates at the bounds of time.Duration, saturated
+// differences are formatted as a bound.
+func formatTimeDifference(got, want time.Time) string {
+	switch d := got.Sub(want); d {
+	case math.MaxInt64:
+		return "more than " + d.String()
+	case math.MinInt64:
+		return "less than " + d.String()
+	default:
+		return d.String()
+	}
+}
+
+// durationDifference calculates got - want. ok is false if the difference
+// overflows time.Duration.
+func durationDifference(got, want time.Duration) (d time.Duration, ok bool) {
+	d = got - want
+	if (want > 0 && d > got) || (want < 0 && d < got) {
+		return 0, false
+	}
+	return d, true
+}
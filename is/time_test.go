@@ -0,0 +1,107 @@
+package is
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+var (
+	timeTestBase = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	timeTestZone = time.FixedZone("CET", 3600)
+)
+
+func TestTimeEqualTo(t *testing.T) {
+	var tb testhelper.TB
+
+	TimeEqualTo(timeTestBase.In(timeTestZone), timeTestBase).Expect(&tb)
+	TimeEqualTo(timeTestBase.Add(time.Second), timeTestBase).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected time 2024-03-01T12:00:00Z but got 2024-03-01T12:00:01Z (difference 1s)",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestTimeWithin(t *testing.T) {
+	var tb testhelper.TB
+
+	TimeWithin(timeTestBase.Add(-time.Second), timeTestBase, time.Second).Expect(&tb)
+	TimeWithin(timeTestBase.Add(-2*time.Second), timeTestBase, time.Second).Expect(&tb)
+	TimeWithin(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), timeTestBase, time.Second).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected time 2024-03-01T11:59:58Z to be within 1s of 2024-03-01T12:00:00Z but difference is -2s",
+			"expected time 0001-01-01T00:00:00Z to be within 1s of 2024-03-01T12:00:00Z but difference is less than -2562047h47m16.854775808s",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestTimeBeforeAfterBetween(t *testing.T) {
+	var tb testhelper.TB
+
+	later := timeTestBase.Add(time.Hour)
+
+	TimeBefore(timeTestBase, later).Expect(&tb)
+	TimeBefore(later, timeTestBase).Expect(&tb)
+	TimeAfter(later, timeTestBase).Expect(&tb)
+	TimeAfter(timeTestBase, timeTestBase).Expect(&tb)
+	TimeBetween(timeTestBase, timeTestBase, later).Expect(&tb)
+	TimeBetween(later.Add(time.Nanosecond), timeTestBase, later).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected time 2024-03-01T13:00:00Z to be before 2024-03-01T12:00:00Z",
+			"expected time 2024-03-01T12:00:00Z to be after 2024-03-01T12:00:00Z",
+			"expected time 2024-03-01T13:00:00.000000001Z to be between 2024-03-01T12:00:00Z and 2024-03-01T13:00:00Z",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestTimeInLocation(t *testing.T) {
+	var tb testhelper.TB
+
+	TimeInLocation(timeTestBase, time.UTC).Expect(&tb)
+	TimeInLocation(timeTestBase.In(timeTestZone), time.UTC).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected time 2024-03-01T13:00:00+01:00 to be in location UTC but got CET",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestDurationWithin(t *testing.T) {
+	var tb testhelper.TB
+
+	DurationWithin(1500*time.Millisecond, time.Second, time.Second).Expect(&tb)
+	DurationWithin(3*time.Second, time.Second, time.Second).Expect(&tb)
+	DurationWithin(math.MinInt64, time.Second, time.Second).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected duration 3s to be within 1s of 1s but difference is 2s",
+			"expected duration -2562047h47m16.854775808s to be within 1s of 1s but difference exceeds 2562047h47m16.854775807s",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
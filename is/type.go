@@ -0,0 +1,102 @@
+package is
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/halimath/expect"
+)
+
+// OfType expects got's dynamic type to be T. If the type assertion succeeds, the expectation created by
+// calling f with the typed value is run as well. f may be nil in which case only the type is checked.
+//
+// T may also be an interface type in which case OfType expects got to implement T.
+func OfType[T any](got any, f func(T) expect.Expectation) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		v, ok := got.(T)
+		if !ok {
+			t.Errorf("expected value of type %s but got %s", qualifiedTypeName(reflect.TypeOf((*T)(nil)).Elem()), qualifiedTypeName(reflect.TypeOf(got)))
+			return
+		}
+
+		if f != nil {
+			f(v).Expect(t)
+		}
+	}), got, f)
+}
+
+// Implementing expects got's dynamic type to implement the interface type I.
+func Implementing[I any](got any) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		iface := reflect.TypeOf((*I)(nil)).Elem()
+		if iface.Kind() != reflect.Interface {
+			t.Errorf("expected an interface type to test for implementation but got %s", qualifiedTypeName(iface))
+			return
+		}
+
+		typ := reflect.TypeOf(got)
+		if typ == nil || !typ.Implements(iface) {
+			t.Errorf("expected value of type %s to implement %s", qualifiedTypeName(typ), qualifiedTypeName(iface))
+		}
+	}), got)
+}
+
+// SameAs expects got and want to point to the same object, i.e. to be identical pointers. Use DeepEqualTo to
+// compare the values pointed to.
+func SameAs[T any](got, want *T) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		if got != want {
+			t.Errorf("expected %p to be the same pointer as %p%s", got, want, describePointees(got, want))
+		}
+	}), got, want)
+}
+
+func describePointees[T any](got, want *T) string {
+	if got == nil || want == nil {
+		return ""
+	}
+	return fmt.Sprintf("\nwant: %v\ngot:  %v", *want, *got)
+}
+
+// qualifiedTypeName returns a string representation of t including the full
+// package path of all named types.
+func qualifiedTypeName(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		return t.PkgPath() + "." + t.Name()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + qualifiedTypeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + qualifiedTypeName(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), qualifiedTypeName(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", qualifiedTypeName(t.Key()), qualifiedTypeName(t.Elem()))
+	case reflect.Chan:
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + qualifiedTypeName(t.Elem())
+		case reflect.SendDir:
+			return "chan<- " + qualifiedTypeName(t.Elem())
+		default:
+			return "chan " + qualifiedTypeName(t.Elem())
+		}
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,83 @@
+package is
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/halimath/expect"
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestOfType(t *testing.T) {
+	var tb testhelper.TB
+
+	var r io.Reader = bufio.NewReader(strings.NewReader("foo"))
+
+	OfType[*bufio.Reader](r, nil).Expect(&tb)
+	OfType(r, func(br *bufio.Reader) expect.Expectation {
+		return EqualTo(br.Size(), 16)
+	}).Expect(&tb)
+	OfType[io.ByteReader](r, nil).Expect(&tb)
+	OfType[*strings.Reader](r, nil).Expect(&tb)
+	OfType[[]*bytes.Buffer](nil, nil).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"values are not equal\nwant: 16\ngot:  4096",
+			"expected value of type *strings.Reader but got *bufio.Reader",
+			"expected value of type []*bytes.Buffer but got <nil>",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+type typeTestStringer struct{}
+
+func (typeTestStringer) String() string { return "" }
+
+func TestImplementing(t *testing.T) {
+	var tb testhelper.TB
+
+	Implementing[fmt.Stringer](typeTestStringer{}).Expect(&tb)
+	Implementing[io.Reader](typeTestStringer{}).Expect(&tb)
+	Implementing[io.Reader](nil).Expect(&tb)
+	Implementing[string]("foo").Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"expected value of type github.com/halimath/expect/is.typeTestStringer to implement io.Reader",
+			"expected value of type <nil> to implement io.Reader",
+			"expected an interface type to test for implementation but got string",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSameAs(t *testing.T) {
+	var tb testhelper.TB
+
+	a, b := 1, 1
+
+	SameAs(&a, &a).Expect(&tb)
+	SameAs(&a, &b).Expect(&tb)
+	SameAs(nil, &b).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			fmt.Sprintf("expected %p to be the same pointer as %p\nwant: 1\ngot:  1", &a, &b),
+			fmt.Sprintf("expected %p to be the same pointer as %p", (*int)(nil), &b),
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
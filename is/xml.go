@@ -0,0 +1,322 @@
+package is
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/halimath/expect"
+)
+
+// XMLDocument is a constraint for types holding an XML document in its
+// textual representation.
+type XMLDocument interface {
+	~string | ~[]byte
+}
+
+// XMLOpt defines an interface for types that can be used as options for
+// XMLEqualTo.
+type XMLOpt interface {
+	xmlOpt()
+}
+
+// IgnoreWhitespaceText is an XMLOpt that defines whether text nodes
+// consisting of whitespace only are ignored.
+type IgnoreWhitespaceText bool
+
+func (IgnoreWhitespaceText) xmlOpt() {}
+
+// IgnoreComments is an XMLOpt that defines whether comments are ignored.
+type IgnoreComments bool
+
+func (IgnoreComments) xmlOpt() {}
+
+// IgnoreProcessingInstructions is an XMLOpt that defines whether processing
+// instructions are ignored. The XML declaration (<?xml version="1.0"?>) is
+// always ignored.
+type IgnoreProcessingInstructions bool
+
+func (IgnoreProcessingInstructions) xmlOpt() {}
+
+// XMLEqualTo expects got and want to contain semantically equal XML documents. Both documents are parsed into
+// a tree of nodes which are then compared. Element names are compared including their namespace, so the
+// namespace prefixes being used do not matter. Attributes are compared ignoring their order. Text, comments
+// and processing instructions are compared as well; use opts to ignore some of them.
+//
+// Differences are reported in the same format used by DeepEqualTo with an XPath-like location such as
+// /feed/entry[2]/title/text() or /feed/@lang.
+func XMLEqualTo[G, W XMLDocument](got G, want W, opts ...XMLOpt) expect.Expectation {
+	return expect.WithArguments(expect.ExpectFunc(func(t expect.TB) {
+		t.Helper()
+
+		var o xmlOptions
+		for _, opt := range opts {
+			switch x := opt.(type) {
+			case IgnoreWhitespaceText:
+				o.ignoreWhitespaceText = bool(x)
+			case IgnoreComments:
+				o.ignoreComments = bool(x)
+			case IgnoreProcessingInstructions:
+				o.ignoreProcInsts = bool(x)
+			}
+		}
+
+		gotNode, err := parseXML([]byte(got), o)
+		if err != nil {
+			t.Errorf("failed to parse got as XML: %v", err)
+			return
+		}
+
+		wantNode, err := parseXML([]byte(want), o)
+		if err != nil {
+			t.Errorf("failed to parse want as XML: %v", err)
+			return
+		}
+
+		var d diff
+		diffXMLChildren(&d, "", wantNode.children, gotNode.children)
+
+		if len(d) > 0 {
+			t.Errorf("XML documents are not equal:%s", d)
+		}
+	}), got, want)
+}
+
+type xmlOptions struct {
+	ignoreWhitespaceText bool
+	ignoreComments       bool
+	ignoreProcInsts      bool
+}
+
+type xmlNodeKind int
+
+const (
+	xmlElement xmlNodeKind = iota
+	xmlText
+	xmlComment
+	xmlProcInst
+)
+
+// xmlNode is a node of a parsed XML document.
+type xmlNode struct {
+	kind     xmlNodeKind
+	name     xml.Name
+	attrs    []xml.Attr
+	data     string
+	children []*xmlNode
+}
+
+func (n *xmlNode) String() string {
+	switch n.kind {
+	case xmlElement:
+		return "<" + formatXMLName(n.name) + ">"
+	case xmlText:
+		return fmt.Sprintf("%q", n.data)
+	case xmlComment:
+		return "<!--" + n.data + "-->"
+	default:
+		return "<?" + n.name.Local + " " + n.data + "?>"
+	}
+}
+
+// step returns the path step used for n.
+func (n *xmlNode) step() string {
+	switch n.kind {
+	case xmlElement:
+		return n.name.Local
+	case xmlText:
+		return "text()"
+	case xmlComment:
+		return "comment()"
+	default:
+		return "processing-instruction(" + n.name.Local + ")"
+	}
+}
+
+func formatXMLName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return "{" + n.Space + "}" + n.Local
+}
+
+func parseXML(data []byte, opts xmlOptions) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+
+		switch x := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{kind: xmlElement, name: x.Name}
+			for _, a := range x.Attr {
+				if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+					continue
+				}
+				n.attrs = append(n.attrs, a)
+			}
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(parent.children) > 0 && parent.children[len(parent.children)-1].kind == xmlText {
+				parent.children[len(parent.children)-1].data += string(x)
+			} else {
+				parent.children = append(parent.children, &xmlNode{kind: xmlText, data: string(x)})
+			}
+
+		case xml.Comment:
+			if !opts.ignoreComments {
+				parent.children = append(parent.children, &xmlNode{kind: xmlComment, data: string(x)})
+			}
+
+		case xml.ProcInst:
+			if x.Target != "xml" && !opts.ignoreProcInsts {
+				parent.children = append(parent.children, &xmlNode{kind: xmlProcInst, name: xml.Name{Local: x.Target}, data: string(x.Inst)})
+			}
+		}
+	}
+
+	if opts.ignoreWhitespaceText {
+		removeWhitespaceText(root)
+	}
+
+	// Whitespace outside of the root element is never significant.
+	root.children = filterXMLNodes(root.children, func(n *xmlNode) bool {
+		return n.kind != xmlText || strings.TrimSpace(n.data) != ""
+	})
+
+	return root, nil
+}
+
+func removeWhitespaceText(n *xmlNode) {
+	n.children = filterXMLNodes(n.children, func(c *xmlNode) bool {
+		return c.kind != xmlText || strings.TrimSpace(c.data) != ""
+	})
+
+	for _, c := range n.children {
+		removeWhitespaceText(c)
+	}
+}
+
+func filterXMLNodes(nodes []*xmlNode, keep func(*xmlNode) bool) []*xmlNode {
+	res := nodes[:0]
+	for _, n := range nodes {
+		if keep(n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+func diffXMLElement(d *diff, path string, want, got *xmlNode) {
+	if want.name != got.name {
+		*d = append(*d, diffEntry{path: path, want: want.String(), got: got.String()})
+		return
+	}
+
+	wantAttrs := xmlAttrMap(want.attrs)
+	gotAttrs := xmlAttrMap(got.attrs)
+
+	names := make([]xml.Name, 0, len(wantAttrs)+len(gotAttrs))
+	for n := range wantAttrs {
+		names = append(names, n)
+	}
+	for n := range gotAttrs {
+		if _, ok := wantAttrs[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return formatXMLName(names[i]) < formatXMLName(names[j]) })
+
+	for _, n := range names {
+		w, wok := wantAttrs[n]
+		g, gok := gotAttrs[n]
+		p := path + "/@" + formatXMLName(n)
+
+		switch {
+		case !gok:
+			*d = append(*d, diffEntry{path: p, want: fmt.Sprintf("%q", w), got: "<missing attribute>"})
+		case !wok:
+			*d = append(*d, diffEntry{path: p, want: "<unwanted attribute>", got: fmt.Sprintf("%q", g)})
+		case w != g:
+			*d = append(*d, diffEntry{path: p, want: fmt.Sprintf("%q", w), got: fmt.Sprintf("%q", g)})
+		}
+	}
+
+	diffXMLChildren(d, path, want.children, got.children)
+}
+
+func xmlAttrMap(attrs []xml.Attr) map[xml.Name]string {
+	m := make(map[xml.Name]string, len(attrs))
+	for _, a := range attrs {
+		m[a.Name] = a.Value
+	}
+	return m
+}
+
+func diffXMLChildren(d *diff, path string, want, got []*xmlNode) {
+	wantPaths := xmlChildPaths(path, want)
+	gotPaths := xmlChildPaths(path, got)
+
+	for i := 0; i < len(want) || i < len(got); i++ {
+		switch {
+		case i >= len(got):
+			*d = append(*d, diffEntry{path: wantPaths[i], want: want[i].String(), got: "<missing node>"})
+
+		case i >= len(want):
+			*d = append(*d, diffEntry{path: gotPaths[i], want: "<unwanted node>", got: got[i].String()})
+
+		case want[i].kind != got[i].kind:
+			*d = append(*d, diffEntry{path: wantPaths[i], want: want[i].String(), got: got[i].String()})
+
+		case want[i].kind == xmlElement:
+			diffXMLElement(d, wantPaths[i], want[i], got[i])
+
+		case want[i].name != got[i].name || want[i].data != got[i].data:
+			*d = append(*d, diffEntry{path: wantPaths[i], want: want[i].String(), got: got[i].String()})
+		}
+	}
+}
+
+// xmlChildPaths returns the XPath-like paths of nodes being children of the
+// node at path. Positional predicates are only added if a parent contains
+// multiple children with the same step.
+func xmlChildPaths(path string, nodes []*xmlNode) []string {
+	counts := make(map[string]int, len(nodes))
+	for _, n := range nodes {
+		counts[n.step()]++
+	}
+
+	positions := make(map[string]int, len(nodes))
+	paths := make([]string, len(nodes))
+	for i, n := range nodes {
+		step := n.step()
+		positions[step]++
+		if counts[step] > 1 {
+			paths[i] = fmt.Sprintf("%s/%s[%d]", path, step, positions[step])
+		} else {
+			paths[i] = path + "/" + step
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,65 @@
+package is
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func TestXMLEqualTo(t *testing.T) {
+	var tb testhelper.TB
+
+	XMLEqualTo(
+		`<?xml version="1.0"?><a:feed xmlns:a="urn:feed" lang="en" id="1"><a:title>foo</a:title></a:feed>`,
+		[]byte(`<feed xmlns="urn:feed" id="1" lang="en"><title>foo</title></feed>`),
+	).Expect(&tb)
+
+	XMLEqualTo(
+		`<feed lang="de" extra="x"><entry><title>a</title></entry><entry><title>b</title></entry><entry/></feed>`,
+		`<feed lang="en" id="1"><entry><title>a</title></entry><entry><title>c</title></entry></feed>`,
+	).Expect(&tb)
+
+	XMLEqualTo(`<feed xmlns="urn:other"/>`, `<feed xmlns="urn:feed"/>`).Expect(&tb)
+	XMLEqualTo(`<feed>`, `<feed/>`).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"XML documents are not equal:" +
+				"\n  at /feed/@extra\n    want: <unwanted attribute>\n     got: \"x\"" +
+				"\n  at /feed/@id\n    want: \"1\"\n     got: <missing attribute>" +
+				"\n  at /feed/@lang\n    want: \"en\"\n     got: \"de\"" +
+				"\n  at /feed/entry[2]/title/text()\n    want: \"c\"\n     got: \"b\"" +
+				"\n  at /feed/entry[3]\n    want: <unwanted node>\n     got: <entry>",
+			"XML documents are not equal:\n  at /feed\n    want: <{urn:feed}feed>\n     got: <{urn:other}feed>",
+			"failed to parse got as XML: XML syntax error on line 1: unexpected EOF",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestXMLEqualTo_options(t *testing.T) {
+	var tb testhelper.TB
+
+	got := `<feed>
+		<!-- generated -->
+		<?render fast?>
+		<title>foo</title>
+	</feed>`
+
+	XMLEqualTo(got, `<feed><title>foo</title></feed>`, IgnoreWhitespaceText(true), IgnoreComments(true), IgnoreProcessingInstructions(true)).Expect(&tb)
+	XMLEqualTo(got, `<feed><?render slow?><title>foo</title></feed>`, IgnoreWhitespaceText(true), IgnoreComments(true)).Expect(&tb)
+	XMLEqualTo(got, `<feed><!-- other --><title>foo</title></feed>`, IgnoreWhitespaceText(true), IgnoreProcessingInstructions(true)).Expect(&tb)
+
+	if !reflect.DeepEqual(tb, testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"XML documents are not equal:\n  at /feed/processing-instruction(render)\n    want: <?render slow?>\n     got: <?render fast?>",
+			"XML documents are not equal:\n  at /feed/comment()\n    want: <!-- other -->\n     got: <!-- generated -->",
+		},
+	}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
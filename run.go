@@ -0,0 +1,87 @@
+package expect
+
+import "testing"
+
+// Runner is an optional interface implemented by TB values that support running subtests. *testing.T and
+// *testing.B are supported by Run as well although their Run methods use their concrete types.
+type Runner interface {
+	Run(name string, f func(t TB)) bool
+}
+
+// Run runs f as a subtest of e called name. The Expectations value passed to f inherits e's message prefixes
+// and configuration (such as Explain). Run uses the Run method of the underlying TB if it is a *testing.T,
+// a *testing.B or implements Runner. For all other TB values f is run sequentially with all messages
+// prefixed with name.
+//
+// Run reports whether f succeeded.
+func (e *Expectations) Run(name string, f func(e *Expectations)) bool {
+	e.t.Helper()
+
+	switch t := e.base.(type) {
+	case *testing.T:
+		return t.Run(name, func(t *testing.T) { f(e.derive(t, e.prefix)) })
+	case *testing.B:
+		return t.Run(name, func(b *testing.B) { f(e.derive(b, e.prefix)) })
+	case Runner:
+		return t.Run(name, func(t TB) { f(e.derive(t, e.prefix)) })
+	}
+
+	r := &runTB{TB: e.base}
+	f(e.derive(r, e.prefix+name+": "))
+
+	return !r.failed
+}
+
+// runTB is a TB that records whether a failure has been reported to it. It is used to determine the
+// result of a sequential run of a subtest.
+type runTB struct {
+	TB
+	failed bool
+}
+
+// Unwrap returns the wrapped TB.
+func (r *runTB) Unwrap() TB { return r.TB }
+
+func (r *runTB) Error(args ...any) {
+	r.TB.Helper()
+	r.failed = true
+	r.TB.Error(args...)
+}
+
+func (r *runTB) Errorf(format string, args ...any) {
+	r.TB.Helper()
+	r.failed = true
+	r.TB.Errorf(format, args...)
+}
+
+func (r *runTB) Fail() {
+	r.TB.Helper()
+	r.failed = true
+	r.TB.Fail()
+}
+
+func (r *runTB) Fatal(args ...any) {
+	r.TB.Helper()
+	r.failed = true
+	r.TB.Fatal(args...)
+}
+
+func (r *runTB) Fatalf(format string, args ...any) {
+	r.TB.Helper()
+	r.failed = true
+	r.TB.Fatalf(format, args...)
+}
+
+func (r *runTB) FailNow() {
+	r.TB.Helper()
+	r.failed = true
+	r.TB.FailNow()
+}
+
+// Parallel signals that the test using e is to be run in parallel with other parallel tests. It calls the
+// Parallel method of the underlying TB if it provides one (as *testing.T does) and is a no-op otherwise.
+func (e *Expectations) Parallel() {
+	if p, ok := e.base.(interface{ Parallel() }); ok {
+		p.Parallel()
+	}
+}
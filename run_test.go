@@ -0,0 +1,100 @@
+package expect
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+type runnerTB struct {
+	testhelper.TB
+	names []string
+}
+
+func (r *runnerTB) Run(name string, f func(t TB)) bool {
+	r.names = append(r.names, name)
+	f(r)
+	return !r.Failed()
+}
+
+func TestExpectations_Run(t *testing.T) {
+	parent := Using(t).WithMessage("outer").Explain()
+
+	for _, name := range []string{"first", "second"} {
+		name := name
+
+		parent.Run(name, func(e *Expectations) {
+			e.Parallel()
+
+			if _, ok := e.base.(*testing.T); !ok || !strings.HasSuffix(e.base.Name(), "/"+name) {
+				t.Errorf("expected subtest %s but got %s", name, e.base.Name())
+			}
+
+			if e.prefix != "outer: " || !e.explain {
+				t.Errorf("expected configuration to be inherited but got %q, %v", e.prefix, e.explain)
+			}
+
+			e.That(pass)
+		})
+	}
+}
+
+func TestExpectations_Run_runner(t *testing.T) {
+	var tb runnerTB
+
+	ok := WithMessage(&tb, "outer").Run("child", func(e *Expectations) {
+		e.That(failWith("failed"))
+	})
+
+	if ok {
+		t.Error("expected Run to report failure")
+	}
+
+	want := runnerTB{
+		TB: testhelper.TB{
+			ErrFlag: true,
+			Logs:    []string{`outer: failWith("failed"): failed`},
+		},
+		names: []string{"child"},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestExpectations_Run_fallback(t *testing.T) {
+	var tb testhelper.TB
+
+	e := WithMessage(&tb, "outer")
+
+	first := e.Run("first", func(e *Expectations) {
+		e.Parallel()
+		e.That(pass)
+	})
+
+	second := e.Run("second", func(e *Expectations) {
+		e.Run("nested", func(e *Expectations) {
+			e.That(Fail)
+		})
+	})
+
+	third := e.Run("third", func(e *Expectations) {
+		e.That(pass)
+	})
+
+	if !first || second || !third {
+		t.Errorf("expected first and third run to succeed and second to fail but got %v, %v, %v", first, second, third)
+	}
+
+	want := testhelper.TB{
+		ErrFlag: true,
+		Logs:    []string{"outer: second: nested: Fail: test failed"},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
@@ -0,0 +1,259 @@
+package expect
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ShowSourceExpressions controls whether That prefixes failures with the source text of the failing
+// expectation, i.e. "is.EqualTo(resp.StatusCode, 200): values are not equal". The source file containing the
+// call to That is located and parsed once when the first expectation fails; passing expectations don't
+// access the source. If the source is not available (i.e. when
+// tests are built using -trimpath) failures are reported without the expression. Set this to false (i.e.
+// from TestMain) to turn this feature off.
+var ShowSourceExpressions = true
+
+// libraryDir contains the directory of this package's source files. Calls
+// to That from non-test files below this directory are never annotated.
+var libraryDir = func() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return ""
+	}
+	return filepath.Dir(file) + string(filepath.Separator)
+}()
+
+// sourceFile is a parsed source file.
+type sourceFile struct {
+	fset *token.FileSet
+	file *ast.File
+	src  []byte
+
+	callsOnce sync.Once
+	// calls contains all calls to That by the line of their opening
+	// parenthesis.
+	calls map[int][]*ast.CallExpr
+
+	importsOnce sync.Once
+	importNames map[string]string
+}
+
+var (
+	sourceFilesMutex sync.Mutex
+	sourceFiles      = make(map[string]*sourceFile)
+)
+
+// loadSourceFile parses the file name caching the result. It returns nil if
+// the file cannot be read or parsed.
+func loadSourceFile(name string) *sourceFile {
+	sourceFilesMutex.Lock()
+	defer sourceFilesMutex.Unlock()
+
+	if f, ok := sourceFiles[name]; ok {
+		return f
+	}
+
+	var sf *sourceFile
+	if src, err := os.ReadFile(name); err == nil {
+		fset := token.NewFileSet()
+		if file, err := parser.ParseFile(fset, name, src, 0); err == nil {
+			sf = &sourceFile{fset: fset, file: file, src: src}
+		}
+	}
+
+	sourceFiles[name] = sf
+	return sf
+}
+
+// callSource contains the parsed source of a call to That.
+type callSource struct {
+	sf *sourceFile
+	// args contains the argument expressions matching the expectations
+	// passed to That.
+	args []ast.Expr
+}
+
+// callSite is the location of a call to That. The call's source is only
+// located when it is needed for the first time, i.e. when an expectation
+// fails, as locating it requires parsing the calling file.
+type callSite struct {
+	pc     uintptr
+	n      int
+	withTB bool
+
+	once sync.Once
+	src  *callSource
+}
+
+// newCallSite captures the call to That found at the given number of stack
+// frames above the caller. n defines the number of expectations passed to
+// That and withTB whether the call receives a TB as its first argument. It
+// returns nil if the caller cannot be determined.
+func newCallSite(skip int, n int, withTB bool) *callSite {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return nil
+	}
+
+	return &callSite{pc: pcs[0], n: n, withTB: withTB}
+}
+
+// source returns the source of the call or nil if it cannot be determined.
+func (c *callSite) source() *callSource {
+	c.once.Do(func() {
+		frame, _ := runtime.CallersFrames([]uintptr{c.pc}).Next()
+		c.src = findCallSource(frame.File, frame.Line, c.n, c.withTB)
+	})
+
+	return c.src
+}
+
+// findCallSource locates the source of the call to That at line in file. It
+// returns nil if the source cannot be determined.
+func findCallSource(file string, line int, n int, withTB bool) *callSource {
+	if file == "" {
+		return nil
+	}
+
+	if libraryDir != "" && strings.HasPrefix(file, libraryDir) && !strings.HasSuffix(file, "_test.go") {
+		return nil
+	}
+
+	sf := loadSourceFile(file)
+	if sf == nil {
+		return nil
+	}
+
+	call := sf.thatCall(line)
+	if call == nil || call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	args := call.Args
+	if withTB {
+		if len(args) == 0 {
+			return nil
+		}
+		args = args[1:]
+	}
+
+	if len(args) != n {
+		return nil
+	}
+
+	return &callSource{sf: sf, args: args}
+}
+
+// thatCall returns the call of a function or method named That whose
+// opening parenthesis is on line, which is the line runtime.Caller reports
+// for a call. It returns nil if there is no such call or if multiple calls
+// share the line (i.e. when calls to That are chained on a single line) as
+// the caller cannot be determined unambiguously. All calls are indexed by
+// line when the file is inspected for the first time.
+func (sf *sourceFile) thatCall(line int) *ast.CallExpr {
+	sf.callsOnce.Do(func() {
+		sf.calls = make(map[int][]*ast.CallExpr)
+
+		ast.Inspect(sf.file, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && sf.isThatFunc(call.Fun) {
+				l := sf.fset.Position(call.Lparen).Line
+				sf.calls[l] = append(sf.calls[l], call)
+			}
+			return true
+		})
+	})
+
+	if calls := sf.calls[line]; len(calls) == 1 {
+		return calls[0]
+	}
+
+	return nil
+}
+
+// packagePath is the import path of this package.
+var packagePath = reflect.TypeOf(Expectations{}).PkgPath()
+
+// isThatFunc reports whether fun refers to this package's That function or
+// to a method named That. Calls of That qualified by the name of another
+// imported package (i.e. assert.That) as well as unqualified calls in files
+// neither belonging to this package nor dot-importing it are not matched.
+// As the file is not type checked, methods named That of types other than
+// Expectations can't be told apart and are matched as well.
+func (sf *sourceFile) isThatFunc(fun ast.Expr) bool {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name == "That" && (sf.file.Name.Name == "expect" || sf.imports()["."] == packagePath)
+	case *ast.SelectorExpr:
+		if f.Sel.Name != "That" {
+			return false
+		}
+		if x, ok := f.X.(*ast.Ident); ok && x.Obj == nil {
+			if path, ok := sf.imports()[x.Name]; ok {
+				return path == packagePath
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// imports maps the names of all packages imported by sf to their paths.
+// Dot imports are mapped using the name ".".
+func (sf *sourceFile) imports() map[string]string {
+	sf.importsOnce.Do(func() {
+		sf.importNames = make(map[string]string)
+
+		for _, spec := range sf.file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+
+			name := importName(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			sf.importNames[name] = path
+		}
+	})
+
+	return sf.importNames
+}
+
+// importName returns the default name of the package imported using path.
+// A major version suffix (i.e. /v2) is skipped.
+func importName(path string) string {
+	elements := strings.Split(path, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && majorVersion.MatchString(name) {
+		name = elements[len(elements)-2]
+	}
+	return name
+}
+
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+var lineBreakWithIndent = regexp.MustCompile(`\s*\n\s*`)
+
+// text returns the source text of n with line breaks and indentation
+// collapsed.
+func (sf *sourceFile) text(n ast.Node) string {
+	start := sf.fset.Position(n.Pos()).Offset
+	end := sf.fset.Position(n.End()).Offset
+
+	s := lineBreakWithIndent.ReplaceAllString(string(sf.src[start:end]), " ")
+	s = strings.ReplaceAll(s, "( ", "(")
+	s = strings.ReplaceAll(s, ", )", ")")
+
+	return s
+}
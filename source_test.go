@@ -0,0 +1,167 @@
+package expect
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+func failWith(msg string) Expectation {
+	return ExpectFunc(func(t TB) { t.Error(msg) })
+}
+
+var pass = ExpectFunc(func(TB) {})
+
+func TestThat_sourceExpressions(t *testing.T) {
+	var tb testhelper.TB
+
+	That(&tb, pass, failWith("first"))
+	That(&tb,
+		failWith(
+			"second",
+		),
+		pass,
+	)
+	Using(&tb).That(failWith("third"))
+	WithMessage(&tb, "prefix").That(pass, failWith("fourth"))
+
+	all := []Expectation{failWith("fifth")}
+	That(&tb, all...)
+
+	want := testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			`failWith("first"): first`,
+			`failWith("second"): second`,
+			`failWith("third"): third`,
+			`prefix: failWith("fourth"): fourth`,
+			`fifth`,
+		},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestThat_sourceExpressionsChained(t *testing.T) {
+	var tb testhelper.TB
+
+	Using(&tb).That(failWith("first")).That(failWith("second"))
+	Using(&tb).
+		That(failWith("third")).
+		That(failWith("fourth"))
+	That(&tb, ExpectFunc(func(t TB) {
+		Using(t).That(failWith("fifth"))
+	}))
+
+	want := testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"first",
+			"second",
+			`failWith("third"): third`,
+			`failWith("fourth"): fourth`,
+			`ExpectFunc(func(t TB) { Using(t).That(failWith("fifth")) }): failWith("fifth"): fifth`,
+		},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestThat_sourceExpressionsDisabled(t *testing.T) {
+	ShowSourceExpressions = false
+	defer func() { ShowSourceExpressions = true }()
+
+	var tb testhelper.TB
+
+	That(&tb, failWith("failed"))
+
+	want := testhelper.TB{
+		ErrFlag: true,
+		Logs:    []string{"failed"},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestLoadSourceFile_notFound(t *testing.T) {
+	if sf := loadSourceFile("does/not/exist.go"); sf != nil {
+		t.Errorf("expected nil but got %#v", sf)
+	}
+}
+
+func TestThat_sourceResolvedOnFailure(t *testing.T) {
+	var tb testhelper.TB
+
+	e := Using(&tb)
+	var site *callSite
+
+	// That shadows the package function to capture the call site.
+	That := func(expectations ...Expectation) {
+		site = e.callSite(1, len(expectations), false)
+		e.that(site, expectations)
+	}
+
+	That(pass)
+	if site.src != nil {
+		t.Errorf("expected source not to be resolved for passing expectations")
+	}
+
+	That(failWith("failed"))
+	if site.src == nil {
+		t.Errorf("expected source to be resolved for failing expectations")
+	}
+
+	if !reflect.DeepEqual(tb.Logs, []string{`failWith("failed"): failed`}) {
+		t.Errorf("not expected: %#v", tb)
+	}
+}
+
+func TestSourceFile_thatCall(t *testing.T) {
+	src := `package foo
+
+import (
+	"github.com/halimath/expect"
+	"example.com/assert/v2"
+)
+
+func f(t expect.TB, e *expect.Expectations) {
+	expect.That(t, ok); assert.That(t, ok)
+	assert.That(t, ok)
+	That(t, ok)
+	e.That(ok)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "foo.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sf := &sourceFile{fset: fset, file: file, src: []byte(src)}
+
+	tests := map[int]string{
+		9:  "expect.That(t, ok)",
+		10: "",
+		11: "",
+		12: "e.That(ok)",
+	}
+
+	for line, want := range tests {
+		var got string
+		if call := sf.thatCall(line); call != nil {
+			got = sf.text(call)
+		}
+
+		if got != want {
+			t.Errorf("line %d: expected %q but got %q", line, want, got)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package expect
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Case can be embedded in the case type passed to Table to mark cases for focused debugging.
+type Case struct {
+	// Only marks a case to be focused on. If any case is marked Only, all other cases are skipped.
+	Only bool
+	// Skip marks a case to be skipped.
+	Skip bool
+}
+
+func (c Case) tableCase() Case { return c }
+
+// tableCase is implemented by all types embedding Case.
+type tableCase interface {
+	tableCase() Case
+}
+
+// TableOpt defines an interface for types that can be used as options for
+// Table.
+type TableOpt interface {
+	tableOpt()
+}
+
+// ParallelCases is a TableOpt that defines whether the cases of a table are
+// run in parallel.
+type ParallelCases bool
+
+func (ParallelCases) tableOpt() {}
+
+// caseStatus is the outcome of running a single case.
+type caseStatus int
+
+const (
+	casePassed caseStatus = iota
+	caseFailed
+	caseSkipped
+)
+
+func (s caseStatus) String() string {
+	switch s {
+	case caseFailed:
+		return "FAIL"
+	case caseSkipped:
+		return "SKIP"
+	default:
+		return "PASS"
+	}
+}
+
+// Table runs run for every case in cases as a subtest (see Expectations.Run) named by calling name. If name
+// is nil, cases are named by their index. Embed Case in the case type to mark cases with Only or Skip. When a
+// case fails, its %+v dump is logged. If any case fails, a summary table listing the outcome of all cases is
+// logged after all cases have completed. Use opts to run the cases in parallel.
+func Table[C any](t TB, cases []C, name func(C) string, run func(e *Expectations, c C), opts ...TableOpt) {
+	t.Helper()
+
+	var parallel bool
+	for _, opt := range opts {
+		if p, ok := opt.(ParallelCases); ok {
+			parallel = bool(p)
+		}
+	}
+
+	focused := false
+	for _, c := range cases {
+		if m, ok := any(c).(tableCase); ok && m.tableCase().Only {
+			focused = true
+		}
+	}
+
+	e := Using(t)
+	names := make([]string, len(cases))
+	var mutex sync.Mutex
+	statuses := make([]caseStatus, len(cases))
+
+	for i, c := range cases {
+		i, c := i, c
+
+		if name != nil {
+			names[i] = name(c)
+		} else {
+			names[i] = fmt.Sprintf("case %d", i)
+		}
+
+		var skip string
+		if m, ok := any(c).(tableCase); ok {
+			marker := m.tableCase()
+			switch {
+			case marker.Skip:
+				skip = "case is marked Skip"
+			case focused && !marker.Only:
+				skip = "other cases are marked Only"
+			}
+		}
+
+		if skip != "" {
+			statuses[i] = caseSkipped
+			if e.runsSubtests() {
+				e.Run(names[i], func(e *Expectations) { e.base.Skip(skip) })
+			}
+			continue
+		}
+
+		e.Run(names[i], func(e *Expectations) {
+			e.t.Helper()
+
+			dump := fmt.Sprintf("case: %+v", c)
+			markFailed := func() {
+				mutex.Lock()
+				statuses[i] = caseFailed
+				mutex.Unlock()
+			}
+
+			switch e.base.(type) {
+			case *testing.T, *testing.B:
+				// The subtest's status covers all failures, including those
+				// reported through derived Expectations or t itself.
+				base := e.base
+				base.Cleanup(func() {
+					if base.Failed() {
+						markFailed()
+						base.Log(dump)
+					}
+				})
+			default:
+				prefix := e.prefix
+				e = e.derive(newCaseTB(e.base, func(t TB) {
+					markFailed()
+					t.Log(prefix + dump)
+				}), prefix)
+			}
+
+			if parallel {
+				e.Parallel()
+			}
+
+			run(e, c)
+		})
+	}
+
+	summarize := func() {
+		t.Helper()
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if s := tableSummary(names, statuses); s != "" {
+			t.Log(s)
+		}
+	}
+
+	if parallel && e.runsSubtests() {
+		// Parallel subtests complete after the test function returns; cleanup
+		// functions run once they have completed.
+		t.Cleanup(summarize)
+		return
+	}
+
+	summarize()
+}
+
+// caseTB is a TB that calls onFailure once when the first failure of a
+// table case is reported. It is used for TB values whose subtests don't
+// report their status reliably.
+type caseTB struct {
+	TB
+	once      *sync.Once
+	onFailure func(t TB)
+}
+
+// newCaseTB wraps t in a caseTB. If t implements Runner, the result
+// implements Runner as well.
+func newCaseTB(t TB, onFailure func(t TB)) TB {
+	c := &caseTB{TB: t, once: new(sync.Once), onFailure: onFailure}
+	if r, ok := t.(Runner); ok {
+		return &caseRunnerTB{caseTB: c, runner: r}
+	}
+	return c
+}
+
+// Unwrap returns the wrapped TB.
+func (c *caseTB) Unwrap() TB { return c.TB }
+
+func (c *caseTB) failed() {
+	c.once.Do(func() { c.onFailure(c.TB) })
+}
+
+func (c *caseTB) Error(args ...any) {
+	c.TB.Helper()
+	c.TB.Error(args...)
+	c.failed()
+}
+
+func (c *caseTB) Errorf(format string, args ...any) {
+	c.TB.Helper()
+	c.TB.Errorf(format, args...)
+	c.failed()
+}
+
+func (c *caseTB) Fail() {
+	c.TB.Helper()
+	c.TB.Fail()
+	c.failed()
+}
+
+// Fatal records the failure before reporting it as Fatal stops the test's
+// goroutine.
+func (c *caseTB) Fatal(args ...any) {
+	c.TB.Helper()
+	c.failed()
+	c.TB.Fatal(args...)
+}
+
+func (c *caseTB) Fatalf(format string, args ...any) {
+	c.TB.Helper()
+	c.failed()
+	c.TB.Fatalf(format, args...)
+}
+
+func (c *caseTB) FailNow() {
+	c.TB.Helper()
+	c.failed()
+	c.TB.FailNow()
+}
+
+// Parallel forwards to the wrapped TB's Parallel method if it provides one.
+func (c *caseTB) Parallel() {
+	if p, ok := c.TB.(interface{ Parallel() }); ok {
+		p.Parallel()
+	}
+}
+
+// caseRunnerTB is a caseTB for TB values implementing Runner. Failures of
+// nested subtests are attributed to the case.
+type caseRunnerTB struct {
+	*caseTB
+	runner Runner
+}
+
+func (c *caseRunnerTB) Run(name string, f func(t TB)) bool {
+	return c.runner.Run(name, func(t TB) {
+		f(&caseTB{TB: t, once: c.once, onFailure: c.onFailure})
+	})
+}
+
+// runsSubtests reports whether Run starts real subtests for e.
+func (e *Expectations) runsSubtests() bool {
+	switch e.base.(type) {
+	case *testing.T, *testing.B, Runner:
+		return true
+	default:
+		return false
+	}
+}
+
+// tableSummary formats a table listing the outcome of all cases. It returns
+// an empty string if no case failed.
+func tableSummary(names []string, statuses []caseStatus) string {
+	failed := 0
+	for _, s := range statuses {
+		if s == caseFailed {
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d cases failed:", failed, len(statuses))
+	for i, s := range statuses {
+		fmt.Fprintf(&b, "\n  %s  %s", s, names[i])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package expect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/halimath/expect/internal/testhelper"
+)
+
+type tableTestCase struct {
+	Case
+	In   int
+	Want int
+}
+
+func tableTestCaseName(c tableTestCase) string {
+	return "double " + string(rune('0'+c.In))
+}
+
+func runTableTestCase(e *Expectations, c tableTestCase) {
+	e.That(equalTo(c.In*2, c.Want))
+}
+
+func TestTable(t *testing.T) {
+	Table(t, []tableTestCase{
+		{In: 1, Want: 2},
+		{In: 2, Want: 4},
+		{In: 3, Want: 7, Case: Case{Skip: true}},
+	}, tableTestCaseName, func(e *Expectations, c tableTestCase) {
+		if c.Skip {
+			t.Errorf("expected case %+v to be skipped", c)
+		}
+		runTableTestCase(e, c)
+	}, ParallelCases(true))
+}
+
+func TestTable_failure(t *testing.T) {
+	var tb testhelper.TB
+
+	Table(&tb, []tableTestCase{
+		{In: 1, Want: 2},
+		{In: 2, Want: 5},
+		{In: 3, Want: 7, Case: Case{Skip: true}},
+	}, tableTestCaseName, runTableTestCase)
+
+	want := testhelper.TB{
+		ErrFlag: true,
+		Logs: []string{
+			"double 2: equalTo(c.In*2, c.Want): values are not equal",
+			"double 2: case: {Case:{Only:false Skip:false} In:2 Want:5}",
+			"1 of 3 cases failed:\n  PASS  double 1\n  FAIL  double 2\n  SKIP  double 3",
+		},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestTable_derivedExpectations(t *testing.T) {
+	cases := []tableTestCase{
+		{In: 1, Want: 2},
+		{In: 2, Want: 5},
+	}
+
+	runCase := func(e *Expectations, c tableTestCase) {
+		e.WithMessage("in %d", c.In).That(equalTo(c.In*2, c.Want))
+	}
+
+	t.Run("fallback", func(t *testing.T) {
+		var tb testhelper.TB
+
+		Table(&tb, cases, tableTestCaseName, runCase)
+
+		want := testhelper.TB{
+			ErrFlag: true,
+			Logs: []string{
+				"double 2: in 2: equalTo(c.In*2, c.Want): values are not equal",
+				"double 2: case: {Case:{Only:false Skip:false} In:2 Want:5}",
+				"1 of 2 cases failed:\n  PASS  double 1\n  FAIL  double 2",
+			},
+		}
+
+		if !reflect.DeepEqual(tb, want) {
+			t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+		}
+	})
+
+	t.Run("runner", func(t *testing.T) {
+		var tb runnerTB
+
+		Table(&tb, cases, tableTestCaseName, func(e *Expectations, c tableTestCase) {
+			e.Run("nested", func(e *Expectations) {
+				runCase(e, c)
+			})
+		})
+
+		want := runnerTB{
+			TB: testhelper.TB{
+				ErrFlag: true,
+				Logs: []string{
+					"in 2: equalTo(c.In*2, c.Want): values are not equal",
+					"case: {Case:{Only:false Skip:false} In:2 Want:5}",
+					"1 of 2 cases failed:\n  PASS  double 1\n  FAIL  double 2",
+				},
+			},
+			names: []string{"double 1", "nested", "double 2", "nested"},
+		}
+
+		if !reflect.DeepEqual(tb, want) {
+			t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+		}
+	})
+}
+
+func TestTable_only(t *testing.T) {
+	var tb runnerTB
+	var ran []int
+
+	Table(&tb, []tableTestCase{
+		{In: 1, Want: 3},
+		{In: 2, Want: 4, Case: Case{Only: true}},
+		{In: 3, Want: 6},
+	}, nil, func(e *Expectations, c tableTestCase) {
+		ran = append(ran, c.In)
+		runTableTestCase(e, c)
+	})
+
+	if !reflect.DeepEqual(ran, []int{2}) {
+		t.Errorf("expected only focused case to run but got %v", ran)
+	}
+
+	want := runnerTB{
+		TB: testhelper.TB{
+			SkippedFlag: true,
+			Logs: []string{
+				"other cases are marked Only",
+				"other cases are marked Only",
+			},
+		},
+		names: []string{"case 0", "case 1", "case 2"},
+	}
+
+	if !reflect.DeepEqual(tb, want) {
+		t.Errorf("TB interaction not equal. Wanted %#v but got %#v", want, tb)
+	}
+}
+
+func TestTableSummary(t *testing.T) {
+	if got := tableSummary([]string{"a", "b"}, []caseStatus{casePassed, caseSkipped}); got != "" {
+		t.Errorf("expected no summary but got %q", got)
+	}
+
+	got := tableSummary([]string{"a", "b", "c"}, []caseStatus{caseFailed, casePassed, caseFailed})
+	want := "2 of 3 cases failed:\n  FAIL  a\n  PASS  b\n  FAIL  c"
+
+	if got != want {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+}